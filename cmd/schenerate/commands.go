@@ -0,0 +1,374 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/Jumpaku/schenerate/config"
+	"github.com/Jumpaku/schenerate/files"
+	"github.com/Jumpaku/schenerate/introspect"
+	"github.com/Jumpaku/schenerate/plugin"
+	"github.com/Jumpaku/schenerate/postgres"
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/Jumpaku/schenerate/script"
+	"github.com/Jumpaku/schenerate/snapshot"
+	"github.com/Jumpaku/schenerate/spanner"
+	"github.com/Jumpaku/schenerate/sqlite3"
+	"github.com/Jumpaku/schenerate/tmplgen"
+	"github.com/Jumpaku/schenerate/watch"
+	"github.com/samber/lo"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+)
+
+// connection is the flags to connect to a database.
+type connection struct {
+	driver              string
+	dsn                 string
+	spannerEmulatorHost string
+}
+
+func (c *connection) register(fs *flag.FlagSet) {
+	fs.StringVar(&c.driver, "driver", envOr("SCHENERATE_DRIVER", ""), "driver of the database: postgres, sqlite3 or spanner (env SCHENERATE_DRIVER)")
+	fs.StringVar(&c.dsn, "dsn", envOr("SCHENERATE_DSN", ""), "data source of the database (env SCHENERATE_DSN)")
+	fs.StringVar(&c.spannerEmulatorHost, "spanner-emulator-host", "", "host of the Spanner emulator (env SPANNER_EMULATOR_HOST)")
+}
+
+func (c *connection) open(ctx context.Context) (introspect.Introspector, error) {
+	if c.driver == "" {
+		return nil, usageError(`driver is required`)
+	}
+	if c.dsn == "" {
+		return nil, usageError(`dsn is required`)
+	}
+	if c.spannerEmulatorHost != "" {
+		if err := os.Setenv("SPANNER_EMULATOR_HOST", c.spannerEmulatorHost); err != nil {
+			return nil, fmt.Errorf(`fail to set SPANNER_EMULATOR_HOST: %w`, err)
+		}
+	}
+	in, err := introspect.Open(ctx, c.driver, c.dsn)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to open %s: %w`, c.driver, err)
+	}
+	return in, nil
+}
+
+// selection is the flags to select tables in addition to the patterns given as arguments.
+type selection struct {
+	exclude stringList
+	types   stringList
+	schemas stringList
+	system  bool
+}
+
+func (s *selection) register(fs *flag.FlagSet) {
+	fs.Var(&s.exclude, "exclude", "glob or /regexp/ of the tables to exclude, which can be repeated")
+	fs.Var(&s.types, "type", "type of the tables to select such as table and view, which can be repeated")
+	fs.Var(&s.schemas, "schema", "name of the schema of the tables to select, which can be repeated")
+	fs.BoolVar(&s.system, "system", false, "also select the system tables such as sqlite_schema")
+}
+
+func (s *selection) selector(patterns []string) schema.Selector {
+	return schema.Selector{Include: patterns, Exclude: s.exclude, Types: s.types, Schemas: s.schemas, System: s.system}
+}
+
+// stringList is a flag which can be repeated.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
+
+func parseFlags(fs *flag.FlagSet, stderr io.Writer, args []string, usage string) error {
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: schenerate %s %s\n\nFlags:\n", fs.Name(), usage)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf(`%w: %v`, errFlags, err)
+	}
+	return nil
+}
+
+func runTables(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var conn connection
+	fs := flag.NewFlagSet("tables", flag.ContinueOnError)
+	conn.register(fs)
+	if err := parseFlags(fs, stderr, args, "[flags]"); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageError(`unexpected arguments %q`, fs.Args())
+	}
+
+	in, err := conn.open(ctx)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tables, err := in.ListTables(ctx)
+	if err != nil {
+		return fmt.Errorf(`fail to list tables: %w`, err)
+	}
+	for _, t := range tables {
+		fmt.Fprintln(stdout, qualifiedName(t.Catalog, t.Schema, t.Name))
+	}
+	return nil
+}
+
+func runSchemas(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var (
+		conn   connection
+		sel    selection
+		format string
+		output string
+	)
+	fs := flag.NewFlagSet("schemas", flag.ContinueOnError)
+	conn.register(fs)
+	sel.register(fs)
+	fs.StringVar(&format, "format", "", "format of the snapshot: json or yaml (default: by the extension of -o, or json)")
+	fs.StringVar(&output, "o", "", "path of the output file (default: stdout)")
+	if err := parseFlags(fs, stderr, args, "[flags] [pattern...]"); err != nil {
+		return err
+	}
+
+	f := snapshot.Format(format)
+	switch {
+	case f != "":
+	case output != "":
+		var err error
+		if f, err = snapshot.FormatOf(output); err != nil {
+			return usageError(`%v`, err)
+		}
+	default:
+		f = snapshot.FormatJSON
+	}
+
+	in, err := conn.open(ctx)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	schemas, err := introspect.SelectSchemas(ctx, in, sel.selector(fs.Args()))
+	if err != nil {
+		return err
+	}
+	b, err := marshalSnapshot(conn.driver, f, schemas)
+	if err != nil {
+		return err
+	}
+	if output == "" {
+		_, err = stdout.Write(b)
+		return err
+	}
+	if err := os.WriteFile(output, b, 0644); err != nil {
+		return fmt.Errorf(`fail to write %s: %w`, output, err)
+	}
+	return nil
+}
+
+// marshalSnapshot serializes the schemas into a snapshot of the backend of the driver, which can be loaded by LoadSnapshot of the backend.
+func marshalSnapshot(driver string, f snapshot.Format, schemas schema.Schemas) ([]byte, error) {
+	switch driver {
+	case postgres.DriverName:
+		s, err := postgres.FromUnified(schemas)
+		if err != nil {
+			return nil, fmt.Errorf(`fail to convert schemas: %w`, err)
+		}
+		return s.MarshalSnapshot(f)
+	case sqlite3.DriverName:
+		s, err := sqlite3.FromUnified(schemas)
+		if err != nil {
+			return nil, fmt.Errorf(`fail to convert schemas: %w`, err)
+		}
+		return s.MarshalSnapshot(f)
+	case spanner.DriverName:
+		s, err := spanner.FromUnified(schemas)
+		if err != nil {
+			return nil, fmt.Errorf(`fail to convert schemas: %w`, err)
+		}
+		return s.MarshalSnapshot(f)
+	default:
+		return nil, fmt.Errorf(`snapshots are not supported by driver %q`, driver)
+	}
+}
+
+// parameters is a flag of key=value pairs, which can be repeated.
+type parameters map[string]string
+
+func (p parameters) String() string {
+	return fmt.Sprint(map[string]string(p))
+}
+
+func (p parameters) Set(s string) error {
+	k, v, ok := strings.Cut(s, "=")
+	if !ok {
+		return fmt.Errorf(`key=value expected: %q`, s)
+	}
+	p[k] = v
+	return nil
+}
+
+func runGenerate(ctx context.Context, args []string, _, stderr io.Writer) error {
+	var (
+		conn       connection
+		sel        selection
+		templates  string
+		pluginPath string
+		pluginHash string
+		pluginTime time.Duration
+		scriptPath string
+		params     = parameters{}
+		out        string
+	)
+	fs := flag.NewFlagSet("generate", flag.ContinueOnError)
+	conn.register(fs)
+	sel.register(fs)
+	fs.StringVar(&templates, "templates", "", "directory of the templates")
+	fs.StringVar(&pluginPath, "plugin", "", "executable of the generator plugin or WASI WebAssembly module ending with .wasm, see github.com/Jumpaku/schenerate/plugin")
+	fs.StringVar(&pluginHash, "plugin-sha256", "", "hex-encoded SHA-256 hash of the WebAssembly module of -plugin, which is verified if given")
+	fs.DurationVar(&pluginTime, "plugin-timeout", plugin.DefaultTimeout, "timeout of the WebAssembly module of -plugin")
+	fs.StringVar(&scriptPath, "script", "", "Starlark script of the generator, see github.com/Jumpaku/schenerate/script")
+	fs.Var(params, "param", "parameter of the plugin or the script in the form of key=value, which can be repeated")
+	fs.StringVar(&out, "out", ".", "directory of the generated files")
+	if err := parseFlags(fs, stderr, args, "(-templates <dir> | -plugin <executable> | -script <file>) [flags] [pattern...]"); err != nil {
+		return err
+	}
+	if lo.Count([]bool{templates != "", pluginPath != "", scriptPath != ""}, true) != 1 {
+		return usageError(`exactly one of templates, plugin and script is required`)
+	}
+
+	if pluginHash != "" && !strings.HasSuffix(pluginPath, ".wasm") {
+		return usageError(`plugin-sha256 requires a plugin ending with .wasm`)
+	}
+	if pluginTime <= 0 {
+		return usageError(`plugin-timeout must be positive`)
+	}
+
+	var generate schema.GeneratorWithSchema
+	switch {
+	case strings.HasSuffix(pluginPath, ".wasm"):
+		p := plugin.WASM{Path: pluginPath, SHA256: pluginHash, Parameters: params, Stderr: stderr, Timeout: pluginTime}
+		generate = p.GeneratorWithSchema(ctx, conn.driver, out)
+	case pluginPath != "":
+		p := plugin.Plugin{Command: pluginPath, Parameters: params, Stderr: stderr}
+		generate = p.GeneratorWithSchema(ctx, conn.driver, out)
+	case scriptPath != "":
+		g, err := script.Load(ctx, scriptPath, out, script.WithParameters(params), script.WithPrint(stderr))
+		if err != nil {
+			return err
+		}
+		generate = g.Generate
+	default:
+		g, err := tmplgen.New(os.DirFS(templates), out)
+		if err != nil {
+			return fmt.Errorf(`fail to load templates: %w`, err)
+		}
+		generate = g.Generate
+	}
+
+	in, err := conn.open(ctx)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	schemas, err := introspect.SelectSchemas(ctx, in, sel.selector(fs.Args()))
+	if err != nil {
+		return err
+	}
+	w := &files.Writer{}
+	if err := generate(w, schemas); err != nil {
+		return err
+	}
+	if err := w.SaveAll(); err != nil {
+		return fmt.Errorf(`fail to save files writer: %w`, err)
+	}
+	return nil
+}
+
+func runRun(ctx context.Context, args []string, _, stderr io.Writer) error {
+	var path string
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.StringVar(&path, "config", envOr("SCHENERATE_CONFIG", config.DefaultPath), "path of the config file (env SCHENERATE_CONFIG)")
+	if err := parseFlags(fs, stderr, args, "[-config <file>] [target...]"); err != nil {
+		return err
+	}
+
+	c, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+	c.Stderr = stderr
+	return c.Run(ctx, fs.Args()...)
+}
+
+func runWatch(ctx context.Context, args []string, _, stderr io.Writer) error {
+	var (
+		path     string
+		interval time.Duration
+		debounce time.Duration
+		database bool
+	)
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	fs.StringVar(&path, "config", envOr("SCHENERATE_CONFIG", config.DefaultPath), "path of the config file (env SCHENERATE_CONFIG)")
+	fs.DurationVar(&interval, "interval", time.Second, "interval of polling the inputs")
+	fs.DurationVar(&debounce, "debounce", 300*time.Millisecond, "duration for which the inputs must be unchanged before regeneration")
+	fs.BoolVar(&database, "database", false, "also poll the schemas of the databases specified by dsn")
+	if err := parseFlags(fs, stderr, args, "[-config <file>] [flags] [target...]"); err != nil {
+		return err
+	}
+
+	resolve := func(context.Context) ([]watch.Source, error) {
+		c, err := config.Load(path)
+		if err != nil {
+			return nil, err
+		}
+		return c.WatchSources(database, fs.Args()...)
+	}
+	if _, err := resolve(ctx); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+	return watch.Watcher{
+		// The sources are resolved from the config on every poll since the config itself may change.
+		Sources:  []watch.Source{watch.Files(path), watch.Dynamic(resolve)},
+		Interval: interval,
+		Debounce: debounce,
+		Run: func(ctx context.Context) error {
+			c, err := config.Load(path)
+			if err != nil {
+				return err
+			}
+			c.Stderr = stderr
+			return c.Run(ctx, fs.Args()...)
+		},
+		Report: func(r watch.Result) {
+			switch {
+			case r.Polled:
+				fmt.Fprintf(stderr, "schenerate watch: %s: fail to poll: %v\n", r.Start.Format(time.TimeOnly), r.Err)
+			case r.Err != nil:
+				fmt.Fprintf(stderr, "schenerate watch: %s: fail to generate: %v\n", r.Start.Format(time.TimeOnly), r.Err)
+			default:
+				fmt.Fprintf(stderr, "schenerate watch: %s: generated in %v\n", r.Start.Format(time.TimeOnly), r.Duration.Round(time.Millisecond))
+			}
+		},
+	}.Watch(ctx)
+}
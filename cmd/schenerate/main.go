@@ -0,0 +1,121 @@
+// Command schenerate lists tables, dumps schemas and generates files from templates with the schemas of a database.
+//
+// Usage:
+//
+//	schenerate tables [flags]
+//	schenerate schemas [flags] [pattern...]
+//	schenerate generate (-templates <dir> | -plugin <executable> | -script <file>) [flags] [pattern...]
+//	schenerate run [-config <file>] [target...]
+//	schenerate watch [-config <file>] [flags] [target...]
+//
+// The database is specified by -driver and -dsn, which default to the environment variables SCHENERATE_DRIVER and SCHENERATE_DSN.
+// The drivers are postgres, sqlite3 and spanner, whose data sources are a connection string of pgx, a DSN of go-sqlite3
+// and projects/<project>/instances/<instance>/databases/<database> respectively.
+// The Spanner emulator is used if -spanner-emulator-host or the environment variable SPANNER_EMULATOR_HOST is set.
+// The tables are selected by the patterns, which are globs or regular expressions enclosed in slashes such as "user_*" and "/^users?$/",
+// and the flags -exclude, -type, -schema and -system. All the tables except the system tables are selected if no pattern is given.
+// The generate command executes one of the templates, a generator plugin and a Starlark script,
+// see github.com/Jumpaku/schenerate/plugin and github.com/Jumpaku/schenerate/script.
+// The run command executes the targets declared in a config file, which defaults to schenerate.yaml, see github.com/Jumpaku/schenerate/config.
+// The watch command executes the targets whenever their inputs or the config file change until it is interrupted.
+//
+// It can be run by go generate, e.g.
+//
+//	//go:generate go run github.com/Jumpaku/schenerate/cmd/schenerate generate -driver=sqlite3 -dsn=db.sqlite -templates=templates -out=.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdout, os.Stderr))
+}
+
+type command struct {
+	usage string
+	run   func(ctx context.Context, args []string, stdout, stderr io.Writer) error
+}
+
+var commands = map[string]command{
+	"tables":   {usage: "lists the tables", run: runTables},
+	"schemas":  {usage: "dumps the schemas of the tables as a snapshot in JSON or YAML", run: runSchemas},
+	"generate": {usage: "generates files by executing the templates, the plugin or the script with the schemas of the tables", run: runGenerate},
+	"run":      {usage: "executes the targets declared in the config file", run: runRun},
+	"watch":    {usage: "executes the targets whenever their inputs change", run: runWatch},
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "help" {
+		printUsage(stderr)
+		if len(args) == 0 {
+			return 2
+		}
+		return 0
+	}
+	c, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "schenerate: unknown command %q\n", args[0])
+		printUsage(stderr)
+		return 2
+	}
+	if err := c.run(ctx, args[1:], stdout, stderr); err != nil {
+		switch {
+		case errors.Is(err, flag.ErrHelp):
+			return 0
+		case errors.Is(err, errFlags):
+			// the flag package has already reported the error.
+			return 2
+		case errors.Is(err, errUsage):
+			fmt.Fprintf(stderr, "schenerate %s: %v\n", args[0], err)
+			return 2
+		default:
+			fmt.Fprintf(stderr, "schenerate %s: %v\n", args[0], err)
+			return 1
+		}
+	}
+	return 0
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: schenerate <command> [flags] [args]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, name := range []string{"tables", "schemas", "generate", "run", "watch"} {
+		fmt.Fprintf(w, "  %-10s %s\n", name, commands[name].usage)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `Run "schenerate <command> -h" for the flags of the command.`)
+}
+
+var (
+	errUsage = errors.New("invalid usage")
+	errFlags = errors.New("invalid flags")
+)
+
+func usageError(format string, args ...any) error {
+	return fmt.Errorf(`%w: %s`, errUsage, fmt.Sprintf(format, args...))
+}
+
+func envOr(key, defaultValue string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+	return defaultValue
+}
+
+func qualifiedName(parts ...string) string {
+	var nonEmpty []string
+	for _, p := range parts {
+		if p != "" {
+			nonEmpty = append(nonEmpty, p)
+		}
+	}
+	return strings.Join(nonEmpty, ".")
+}
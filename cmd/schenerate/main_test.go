@@ -0,0 +1,254 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"github.com/Jumpaku/schenerate/sqlite3"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func setupSQLite3(t *testing.T) string {
+	t.Helper()
+	dsn := filepath.Join(t.TempDir(), "db.sqlite")
+	db, err := sql.Open("sqlite3", dsn)
+	require.Nil(t, err)
+	defer db.Close()
+	_, err = db.Exec(`
+CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL);
+CREATE TABLE user_items (id INTEGER PRIMARY KEY, user_id INTEGER NOT NULL REFERENCES users (id));`)
+	require.Nil(t, err)
+	return dsn
+}
+
+func TestRun(t *testing.T) {
+	dsn := setupSQLite3(t)
+	templates := t.TempDir()
+	require.Nil(t, os.WriteFile(filepath.Join(templates, "{{.Schema.Name | singular | snake}}.txt.each.tmpl"), []byte(`{{.Schema.Name | singular | pascal}}`), 0644))
+	script := filepath.Join(t.TempDir(), "gen.star")
+	require.Nil(t, os.WriteFile(script, []byte(`out.add(params["file"]); out.write(",".join([s.name for s in schemas]))`), 0644))
+	out := t.TempDir()
+
+	testcases := []struct {
+		name            string
+		env             map[string]string
+		args            []string
+		wantCode        int
+		wantStdout      string
+		wantContains    []string
+		wantNotContains []string
+		wantFiles       map[string]string
+	}{
+		{
+			name:     "no command",
+			args:     nil,
+			wantCode: 2,
+		},
+		{
+			name:     "unknown command",
+			args:     []string{"unknown"},
+			wantCode: 2,
+		},
+		{
+			name:       "tables",
+			args:       []string{"tables", "-driver=sqlite3", "-dsn=" + dsn},
+			wantCode:   0,
+			wantStdout: "main.sqlite_schema\nmain.user_items\nmain.users\ntemp.sqlite_temp_schema\n",
+		},
+		{
+			name:       "tables with environment variables",
+			env:        map[string]string{"SCHENERATE_DRIVER": "sqlite3", "SCHENERATE_DSN": dsn},
+			args:       []string{"tables"},
+			wantCode:   0,
+			wantStdout: "main.sqlite_schema\nmain.user_items\nmain.users\ntemp.sqlite_temp_schema\n",
+		},
+		{
+			name:     "tables without driver",
+			args:     []string{"tables", "-dsn=" + dsn},
+			wantCode: 2,
+		},
+		{
+			name:     "unknown driver",
+			args:     []string{"tables", "-driver=unknown", "-dsn=" + dsn},
+			wantCode: 1,
+		},
+		{
+			name:     "schemas",
+			args:     []string{"schemas", "-driver=sqlite3", "-dsn=" + dsn, "-format=yaml", "users"},
+			wantCode: 0,
+			wantStdout: `version: 1
+dialect: sqlite3
+schemas:
+  - name: users
+    type: table
+    columns:
+      - name: id
+        type: INTEGER
+        nullable: true
+      - name: name
+        type: TEXT
+        nullable: false
+    primaryKey:
+      - id
+    foreignKeys: []
+    indexes: []
+`,
+		},
+		{
+			name:     "schemas with invalid pattern",
+			args:     []string{"schemas", "-driver=sqlite3", "-dsn=" + dsn, "/(/"},
+			wantCode: 1,
+		},
+		{
+			name:            "schemas with selection",
+			args:            []string{"schemas", "-driver=sqlite3", "-dsn=" + dsn, "-exclude=/items$/", "-type=table", "-schema=main", "user*"},
+			wantCode:        0,
+			wantContains:    []string{`"name": "users"`},
+			wantNotContains: []string{`"name": "user_items"`, `"name": "sqlite_schema"`},
+		},
+		{
+			name:      "generate",
+			args:      []string{"generate", "-driver=sqlite3", "-dsn=" + dsn, "-templates=" + templates, "-out=" + out},
+			wantCode:  0,
+			wantFiles: map[string]string{"user.txt": "User", "user_item.txt": "UserItem"},
+		},
+		{
+			name:      "generate with script",
+			args:      []string{"generate", "-driver=sqlite3", "-dsn=" + dsn, "-script=" + script, "-param=file=tables.txt", "-out=" + out},
+			wantCode:  0,
+			wantFiles: map[string]string{"tables.txt": "user_items,users"},
+		},
+		{
+			name:     "run without config",
+			args:     []string{"run", "-config=" + filepath.Join(out, "unknown.yaml")},
+			wantCode: 1,
+		},
+		{
+			name:     "watch without config",
+			args:     []string{"watch", "-config=" + filepath.Join(out, "unknown.yaml")},
+			wantCode: 1,
+		},
+		{
+			name:     "generate without templates",
+			args:     []string{"generate", "-driver=sqlite3", "-dsn=" + dsn},
+			wantCode: 2,
+		},
+		{
+			name:     "generate with templates and plugin",
+			args:     []string{"generate", "-driver=sqlite3", "-dsn=" + dsn, "-templates=" + templates, "-plugin=gen"},
+			wantCode: 2,
+		},
+		{
+			name:     "generate with hash of non-wasm plugin",
+			args:     []string{"generate", "-driver=sqlite3", "-dsn=" + dsn, "-plugin=gen", "-plugin-sha256=abc"},
+			wantCode: 2,
+		},
+		{
+			name:     "generate with invalid parameter",
+			args:     []string{"generate", "-driver=sqlite3", "-dsn=" + dsn, "-plugin=gen", "-param=invalid"},
+			wantCode: 2,
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			for k, v := range testcase.env {
+				t.Setenv(k, v)
+			}
+			var stdout, stderr bytes.Buffer
+			got := run(context.Background(), testcase.args, &stdout, &stderr)
+			require.Equal(t, testcase.wantCode, got, stderr.String())
+			if testcase.wantStdout != "" {
+				require.Equal(t, testcase.wantStdout, stdout.String())
+			}
+			for _, want := range testcase.wantContains {
+				require.Contains(t, stdout.String(), want)
+			}
+			for _, notWant := range testcase.wantNotContains {
+				require.NotContains(t, stdout.String(), notWant)
+			}
+			for name, want := range testcase.wantFiles {
+				b, err := os.ReadFile(filepath.Join(out, name))
+				require.Nil(t, err)
+				require.Equal(t, want, string(b))
+			}
+		})
+	}
+}
+
+func TestRun_watch(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"schema.sql":           `CREATE TABLE users (id INTEGER PRIMARY KEY);`,
+		"templates/a.txt.tmpl": `{{range .Schemas}}{{.Name}}{{end}}`,
+		"schenerate.yaml":      "sources: {db: {driver: sqlite3, ddl: [schema.sql]}}\ntargets: [{name: a, source: db, templates: templates, out: gen}]\n",
+	} {
+		require.Nil(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+		require.Nil(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	got := run(ctx, []string{"watch", "-config=" + filepath.Join(dir, "schenerate.yaml"), "-interval=10ms"}, &stdout, &stderr)
+	require.Equal(t, 0, got, stderr.String())
+	require.Contains(t, stderr.String(), "generated in")
+	b, err := os.ReadFile(filepath.Join(dir, "gen", "a.txt"))
+	require.Nil(t, err)
+	require.Equal(t, "users", string(b))
+}
+
+func TestRun_watchReloadedSources(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		require.Nil(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+		require.Nil(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	read := func() string {
+		b, _ := os.ReadFile(filepath.Join(dir, "gen", "a.txt"))
+		return string(b)
+	}
+	write("a.sql", `CREATE TABLE users (id INTEGER PRIMARY KEY);`)
+	write("b.sql", `CREATE TABLE items (id INTEGER PRIMARY KEY);`)
+	write("templates/a.txt.tmpl", `{{range .Schemas}}{{.Name}}{{end}}`)
+	write("schenerate.yaml", "sources: {db: {driver: sqlite3, ddl: [a.sql]}}\ntargets: [{name: a, source: db, templates: templates, out: gen}]\n")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	done := make(chan int)
+	go func() {
+		done <- run(ctx, []string{"watch", "-config=" + filepath.Join(dir, "schenerate.yaml"), "-interval=10ms", "-debounce=10ms"}, &stdout, &stderr)
+	}()
+	require.Eventually(t, func() bool { return read() == "users" }, 5*time.Second, 10*time.Millisecond)
+
+	write("schenerate.yaml", "sources: {db: {driver: sqlite3, ddl: [b.sql]}}\ntargets: [{name: a, source: db, templates: templates, out: gen}]\n")
+	require.Eventually(t, func() bool { return read() == "items" }, 5*time.Second, 10*time.Millisecond)
+
+	write("b.sql", `CREATE TABLE orders (id INTEGER PRIMARY KEY);`)
+	require.Eventually(t, func() bool { return read() == "orders" }, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.Equal(t, 0, <-done)
+}
+
+func TestRun_schemasSnapshot(t *testing.T) {
+	dsn := setupSQLite3(t)
+	output := filepath.Join(t.TempDir(), "schemas.json")
+
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"schemas", "-driver=sqlite3", "-dsn=" + dsn, "-o=" + output}, &stdout, &stderr)
+	require.Equal(t, 0, code, stderr.String())
+
+	got, err := sqlite3.LoadSnapshot(output)
+	require.Nil(t, err)
+	q, err := sqlite3.Open(dsn)
+	require.Nil(t, err)
+	defer q.Close()
+	want, err := sqlite3.ListSchemas(context.Background(), q, []string{"user_items", "users"})
+	require.Nil(t, err)
+	require.Equal(t, want, got)
+}
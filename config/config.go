@@ -0,0 +1,270 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/Jumpaku/schenerate/gotype"
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/samber/lo"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// DefaultPath is the default path of the config file.
+const DefaultPath = "schenerate.yaml"
+
+// Config is a project config declaring data sources and targets, which is loaded from a YAML file such as:
+//
+//	sources:
+//	  app:
+//	    driver: postgres
+//	    dsn: ${DATABASE_URL}
+//	  local:
+//	    driver: sqlite3
+//	    ddl: [schema.sql]
+//	targets:
+//	  - name: models
+//	    source: app
+//	    tables: [users, items, "order_*"]
+//	    select:
+//	      exclude: [/_(tmp|old)$/]
+//	      types: [table]
+//	    templates: templates/models
+//	    out: internal/models
+//	    types:
+//	      nullStyle: database/sql
+//	      overrides:
+//	        - type: uuid
+//	          goType: uuid.UUID
+//	          import: github.com/google/uuid
+//	    postProcess:
+//	      - command: gofmt
+//	        args: [-w]
+//	  - name: client
+//	    source: local
+//	    plugin:
+//	      command: ./plugins/client.py
+//	      parameters: {package: client}
+//	    out: web/src/client
+//	  - name: docs
+//	    source: local
+//	    plugin:
+//	      wasm: plugins/docs.wasm
+//	      sha256: 2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae
+//	      timeout: 30s
+//	    out: docs
+//	  - name: fixtures
+//	    source: local
+//	    script:
+//	      path: scripts/fixtures.star
+//	      parameters: {rows: "10"}
+//	    out: testdata
+//
+// Relative paths in the config file are resolved against the directory of the config file.
+type Config struct {
+	Sources map[string]Source `json:"sources" yaml:"sources"`
+	Targets []Target          `json:"targets" yaml:"targets"`
+	// Stderr receives the stderr and the diagnostics of plugins and the outputs of print in scripts, which are discarded if it is nil.
+	Stderr io.Writer `json:"-" yaml:"-"`
+
+	dir string
+}
+
+// Source is a data source of schemas, which is a database specified by Driver and DSN,
+// DDL files applied to a private database, or a migration directory replayed up to Version.
+// DDL files are supported by sqlite3 and spanner, and migrations are supported by sqlite3, spanner and postgres with DSN.
+type Source struct {
+	Driver string `json:"driver" yaml:"driver"`
+	// DSN is the data source of the driver, in which environment variables such as ${DATABASE_URL} are expanded.
+	DSN        string   `json:"dsn" yaml:"dsn"`
+	DDL        []string `json:"ddl" yaml:"ddl"`
+	Migrations string   `json:"migrations" yaml:"migrations"`
+	Version    string   `json:"version" yaml:"version"`
+}
+
+// Target generates files from the schemas of the tables in the source selected by Tables and Select,
+// by one of the templates in the directory Templates, Plugin and Script.
+type Target struct {
+	Name   string `json:"name" yaml:"name"`
+	Source string `json:"source" yaml:"source"`
+	// Tables is the patterns of the tables to select in addition to Select.Include, see schema.Selector.
+	Tables    []string        `json:"tables" yaml:"tables"`
+	Select    schema.Selector `json:"select" yaml:"select"`
+	Templates string          `json:"templates" yaml:"templates"`
+	Plugin    *Plugin         `json:"plugin" yaml:"plugin"`
+	Script    *Script         `json:"script" yaml:"script"`
+	// Out is the directory to which the files are generated, which defaults to the directory of the config file.
+	Out         string    `json:"out" yaml:"out"`
+	Types       Types     `json:"types" yaml:"types"`
+	PostProcess []Command `json:"postProcess" yaml:"postProcess"`
+}
+
+// Types configures the Go types of columns available in the templates by goType and goImports.
+type Types struct {
+	NullStyle gotype.NullStyle `json:"nullStyle" yaml:"nullStyle"`
+	Overrides []TypeOverride   `json:"overrides" yaml:"overrides"`
+}
+
+// TypeOverride overrides the Go types of columns whose types match Type, a pattern of gotype.Mapper.OverrideType,
+// or of the column specified by Column in the form of <table>.<column>.
+// If NullGoType is empty, the pointer to GoType is used for nullable columns.
+type TypeOverride struct {
+	Type       string `json:"type" yaml:"type"`
+	Column     string `json:"column" yaml:"column"`
+	GoType     string `json:"goType" yaml:"goType"`
+	Import     string `json:"import" yaml:"import"`
+	NullGoType string `json:"nullGoType" yaml:"nullGoType"`
+	NullImport string `json:"nullImport" yaml:"nullImport"`
+}
+
+// Plugin is a generator plugin, which is either an executable Command executed in the directory of the config file
+// or a WASI WebAssembly module WASM run in a sandbox and optionally pinned by SHA256, see github.com/Jumpaku/schenerate/plugin.
+type Plugin struct {
+	Command    string            `json:"command" yaml:"command"`
+	Args       []string          `json:"args" yaml:"args"`
+	WASM       string            `json:"wasm" yaml:"wasm"`
+	SHA256     string            `json:"sha256" yaml:"sha256"`
+	Parameters map[string]string `json:"parameters" yaml:"parameters"`
+	// Timeout is the timeout of WASM in the form of time.ParseDuration such as 30s, which defaults to plugin.DefaultTimeout.
+	Timeout string `json:"timeout" yaml:"timeout"`
+	// MemoryLimitPages is the memory limit of WASM in 64 KiB pages, which defaults to plugin.DefaultMemoryLimitPages.
+	MemoryLimitPages uint32 `json:"memoryLimitPages" yaml:"memoryLimitPages"`
+}
+
+// Script is a Starlark script, see github.com/Jumpaku/schenerate/script.
+type Script struct {
+	Path       string            `json:"path" yaml:"path"`
+	Parameters map[string]string `json:"parameters" yaml:"parameters"`
+}
+
+// Command is a post-processor executed in the directory of the config file with the paths of the generated files appended to Args.
+type Command struct {
+	Command string   `json:"command" yaml:"command"`
+	Args    []string `json:"args" yaml:"args"`
+}
+
+// Load loads the config file.
+func Load(path string) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to read config %s: %w`, path, err)
+	}
+	dir, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return nil, fmt.Errorf(`fail to resolve directory of config %s: %w`, path, err)
+	}
+	c, err := Parse(b, dir)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to load config %s: %w`, path, err)
+	}
+	return c, nil
+}
+
+// Parse parses the config in YAML, whose relative paths are resolved against dir.
+func Parse(b []byte, dir string) (*Config, error) {
+	var c Config
+	d := yaml.NewDecoder(bytes.NewReader(b))
+	d.KnownFields(true)
+	if err := d.Decode(&c); err != nil {
+		return nil, fmt.Errorf(`fail to parse config: %w`, err)
+	}
+	c.dir = dir
+	if err := c.validate(); err != nil {
+		return nil, fmt.Errorf(`invalid config: %w`, err)
+	}
+	return &c, nil
+}
+
+func (c *Config) validate() error {
+	sources := lo.Keys(c.Sources)
+	slices.Sort(sources)
+	for _, name := range sources {
+		s := c.Sources[name]
+		if s.Driver == "" {
+			return fmt.Errorf(`source %s: driver is required`, name)
+		}
+		n := 0
+		for _, given := range []bool{s.DSN != "" && s.Migrations == "", len(s.DDL) > 0, s.Migrations != ""} {
+			if given {
+				n++
+			}
+		}
+		if n != 1 {
+			return fmt.Errorf(`source %s: exactly one of dsn, ddl and migrations is required`, name)
+		}
+		if s.Version != "" && s.Migrations == "" {
+			return fmt.Errorf(`source %s: version requires migrations`, name)
+		}
+	}
+	names := map[string]bool{}
+	for i, t := range c.Targets {
+		if t.Name == "" {
+			return fmt.Errorf(`target[%d]: name is required`, i)
+		}
+		if names[t.Name] {
+			return fmt.Errorf(`target %s: duplicated name`, t.Name)
+		}
+		names[t.Name] = true
+		if _, ok := c.Sources[t.Source]; !ok {
+			return fmt.Errorf(`target %s: source %q not found`, t.Name, t.Source)
+		}
+		if lo.Count([]bool{t.Templates != "", t.Plugin != nil, t.Script != nil}, true) != 1 {
+			return fmt.Errorf(`target %s: exactly one of templates, plugin and script is required`, t.Name)
+		}
+		if t.Script != nil && t.Script.Path == "" {
+			return fmt.Errorf(`target %s: script.path is required`, t.Name)
+		}
+		if p := t.Plugin; p != nil {
+			if (p.Command == "") == (p.WASM == "") {
+				return fmt.Errorf(`target %s: exactly one of plugin.command and plugin.wasm is required`, t.Name)
+			}
+			if p.WASM == "" && p.SHA256 != "" {
+				return fmt.Errorf(`target %s: plugin.sha256 requires plugin.wasm`, t.Name)
+			}
+			if p.WASM != "" && len(p.Args) > 0 {
+				return fmt.Errorf(`target %s: plugin.args requires plugin.command`, t.Name)
+			}
+			if p.WASM == "" && (p.Timeout != "" || p.MemoryLimitPages > 0) {
+				return fmt.Errorf(`target %s: plugin.timeout and plugin.memoryLimitPages require plugin.wasm`, t.Name)
+			}
+			if p.Timeout != "" {
+				if d, err := time.ParseDuration(p.Timeout); err != nil || d <= 0 {
+					return fmt.Errorf(`target %s: invalid plugin.timeout %q`, t.Name, p.Timeout)
+				}
+			}
+			if p.MemoryLimitPages > 65536 {
+				return fmt.Errorf(`target %s: plugin.memoryLimitPages must not exceed 65536`, t.Name)
+			}
+		}
+		switch t.Types.NullStyle {
+		case "", gotype.NullStylePointer, gotype.NullStyleDatabaseSQL, gotype.NullStyleDriver:
+		default:
+			return fmt.Errorf(`target %s: unknown types.nullStyle %q`, t.Name, t.Types.NullStyle)
+		}
+		for j, o := range t.Types.Overrides {
+			if (o.Type == "") == (o.Column == "") {
+				return fmt.Errorf(`target %s: types.overrides[%d]: exactly one of type and column is required`, t.Name, j)
+			}
+			if o.GoType == "" {
+				return fmt.Errorf(`target %s: types.overrides[%d]: goType is required`, t.Name, j)
+			}
+		}
+		for j, p := range t.PostProcess {
+			if p.Command == "" {
+				return fmt.Errorf(`target %s: postProcess[%d]: command is required`, t.Name, j)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Config) path(p string) string {
+	if filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(c.dir, p)
+}
@@ -0,0 +1,208 @@
+package config_test
+
+import (
+	"context"
+	"github.com/Jumpaku/schenerate/config"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	testcases := []struct {
+		name    string
+		in      string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			in: `
+sources:
+  db: {driver: postgres, dsn: "${DATABASE_URL}"}
+  ddl: {driver: sqlite3, ddl: [schema.sql]}
+  migrations: {driver: spanner, migrations: migrations, version: "2"}
+targets:
+  - name: models
+    source: db
+    tables: [users]
+    select: {include: ["item*"], exclude: [/_old$/], types: [table, view], schemas: [public], system: true}
+    templates: templates
+    types:
+      nullStyle: database/sql
+      overrides:
+        - {type: uuid, goType: uuid.UUID, import: github.com/google/uuid}
+        - {column: users.id, goType: int64}
+    postProcess:
+      - {command: gofmt, args: [-w]}
+`,
+		},
+		{name: "unknown field", in: "sources: {}\nunknown: 1\n", wantErr: true},
+		{name: "source without driver", in: "sources: {db: {dsn: x}}\n", wantErr: true},
+		{name: "source without data", in: "sources: {db: {driver: sqlite3}}\n", wantErr: true},
+		{name: "source with dsn and ddl", in: "sources: {db: {driver: sqlite3, dsn: x, ddl: [a.sql]}}\n", wantErr: true},
+		{name: "version without migrations", in: "sources: {db: {driver: sqlite3, dsn: x, version: '1'}}\n", wantErr: true},
+		{name: "target without name", in: "sources: {db: {driver: sqlite3, dsn: x}}\ntargets: [{source: db, templates: t}]\n", wantErr: true},
+		{name: "duplicated targets", in: "sources: {db: {driver: sqlite3, dsn: x}}\ntargets: [{name: a, source: db, templates: t}, {name: a, source: db, templates: t}]\n", wantErr: true},
+		{name: "unknown source", in: "sources: {db: {driver: sqlite3, dsn: x}}\ntargets: [{name: a, source: unknown, templates: t}]\n", wantErr: true},
+		{name: "target with plugin", in: "sources: {db: {driver: sqlite3, dsn: x}}\ntargets: [{name: a, source: db, plugin: {command: ./gen, parameters: {k: v}}}]\n"},
+		{name: "target without templates", in: "sources: {db: {driver: sqlite3, dsn: x}}\ntargets: [{name: a, source: db}]\n", wantErr: true},
+		{name: "target with templates and plugin", in: "sources: {db: {driver: sqlite3, dsn: x}}\ntargets: [{name: a, source: db, templates: t, plugin: {command: ./gen}}]\n", wantErr: true},
+		{name: "target with wasm plugin", in: "sources: {db: {driver: sqlite3, dsn: x}}\ntargets: [{name: a, source: db, plugin: {wasm: gen.wasm, sha256: abc}}]\n"},
+		{name: "plugin with command and wasm", in: "sources: {db: {driver: sqlite3, dsn: x}}\ntargets: [{name: a, source: db, plugin: {command: ./gen, wasm: gen.wasm}}]\n", wantErr: true},
+		{name: "sha256 without wasm", in: "sources: {db: {driver: sqlite3, dsn: x}}\ntargets: [{name: a, source: db, plugin: {command: ./gen, sha256: abc}}]\n", wantErr: true},
+		{name: "wasm plugin with limits", in: "sources: {db: {driver: sqlite3, dsn: x}}\ntargets: [{name: a, source: db, plugin: {wasm: gen.wasm, timeout: 30s, memoryLimitPages: 1024}}]\n"},
+		{name: "timeout without wasm", in: "sources: {db: {driver: sqlite3, dsn: x}}\ntargets: [{name: a, source: db, plugin: {command: ./gen, timeout: 30s}}]\n", wantErr: true},
+		{name: "invalid timeout", in: "sources: {db: {driver: sqlite3, dsn: x}}\ntargets: [{name: a, source: db, plugin: {wasm: gen.wasm, timeout: soon}}]\n", wantErr: true},
+		{name: "too large memory limit", in: "sources: {db: {driver: sqlite3, dsn: x}}\ntargets: [{name: a, source: db, plugin: {wasm: gen.wasm, memoryLimitPages: 65537}}]\n", wantErr: true},
+		{name: "target with script", in: "sources: {db: {driver: sqlite3, dsn: x}}\ntargets: [{name: a, source: db, script: {path: gen.star}}]\n"},
+		{name: "target with templates and script", in: "sources: {db: {driver: sqlite3, dsn: x}}\ntargets: [{name: a, source: db, templates: t, script: {path: gen.star}}]\n", wantErr: true},
+		{name: "script without path", in: "sources: {db: {driver: sqlite3, dsn: x}}\ntargets: [{name: a, source: db, script: {parameters: {k: v}}}]\n", wantErr: true},
+		{name: "plugin without command", in: "sources: {db: {driver: sqlite3, dsn: x}}\ntargets: [{name: a, source: db, plugin: {args: [x]}}]\n", wantErr: true},
+		{name: "unknown null style", in: "sources: {db: {driver: sqlite3, dsn: x}}\ntargets: [{name: a, source: db, templates: t, types: {nullStyle: unknown}}]\n", wantErr: true},
+		{name: "override with type and column", in: "sources: {db: {driver: sqlite3, dsn: x}}\ntargets: [{name: a, source: db, templates: t, types: {overrides: [{type: x, column: a.b, goType: int}]}}]\n", wantErr: true},
+		{name: "override without go type", in: "sources: {db: {driver: sqlite3, dsn: x}}\ntargets: [{name: a, source: db, templates: t, types: {overrides: [{type: x}]}}]\n", wantErr: true},
+		{name: "post-process without command", in: "sources: {db: {driver: sqlite3, dsn: x}}\ntargets: [{name: a, source: db, templates: t, postProcess: [{args: [x]}]}]\n", wantErr: true},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			_, err := config.Parse([]byte(testcase.in), t.TempDir())
+			if testcase.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+		})
+	}
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.Nil(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.Nil(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestConfig_Run(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"schema.sql":                            `CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL, note TEXT); CREATE TABLE items (id INTEGER PRIMARY KEY);`,
+		"spanner.sql":                           `CREATE TABLE Users (Id INT64 NOT NULL, Name STRING(MAX)) PRIMARY KEY (Id);`,
+		"models/{{.Schema.Name}}.txt.each.tmpl": `{{range .Schema.Columns}}{{.Name}} {{goType $.Schema .}};{{end}}`,
+		"tables/tables.txt.tmpl":                `{{range .Schemas}}{{.Name}};{{end}}`,
+		"script.star":                           `out.add(params["file"]); out.write(",".join([s.name for s in schemas]))`,
+		"plugin.sh":                             `cat > /dev/null; echo '{"version":1,"files":[{"path":"plugin.txt","content":"'"$1"'"}]}'`,
+		"schenerate.yaml": `
+sources:
+  sqlite: {driver: sqlite3, ddl: [schema.sql]}
+  spanner: {driver: spanner, ddl: [spanner.sql]}
+targets:
+  - name: sqlite-models
+    source: sqlite
+    tables: [users]
+    templates: models
+    out: gen/sqlite
+    types:
+      overrides:
+        - {column: users.note, goType: Note, nullGoType: NullNote}
+    postProcess:
+      - {command: touch, args: [gen/post-processed]}
+  - name: spanner-models
+    source: spanner
+    templates: models
+    out: gen/spanner
+    types:
+      nullStyle: driver
+  - name: tables
+    source: sqlite
+    templates: tables
+    out: gen
+  - name: selected
+    source: sqlite
+    select: {include: ["*s"], exclude: [/^item/], types: [table]}
+    templates: tables
+    out: gen/selected
+  - name: script
+    source: sqlite
+    script: {path: script.star, parameters: {file: script.txt}}
+    out: gen
+  - name: plugin
+    source: sqlite
+    plugin: {command: sh, args: [plugin.sh, generated]}
+    out: gen
+`,
+	})
+
+	c, err := config.Load(filepath.Join(dir, "schenerate.yaml"))
+	require.Nil(t, err)
+
+	require.NotNil(t, c.Run(context.Background(), "unknown"))
+
+	require.Nil(t, c.Run(context.Background(), "sqlite-models", "spanner-models"))
+	for path, want := range map[string]string{
+		"gen/sqlite/users.txt":  `id *int64;name string;note NullNote;`,
+		"gen/spanner/Users.txt": `Id int64;Name spanner.NullString;`,
+		"gen/post-processed":    ``,
+	} {
+		got, err := os.ReadFile(filepath.Join(dir, path))
+		require.Nil(t, err, path)
+		require.Equal(t, want, string(got), path)
+	}
+	require.NoFileExists(t, filepath.Join(dir, "gen/tables.txt"))
+
+	require.Nil(t, c.Run(context.Background()))
+	for path, want := range map[string]string{
+		"gen/tables.txt":          `items;users;`,
+		"gen/plugin.txt":          `generated`,
+		"gen/script.txt":          `items,users`,
+		"gen/selected/tables.txt": `users;`,
+	} {
+		got, err := os.ReadFile(filepath.Join(dir, path))
+		require.Nil(t, err, path)
+		require.Equal(t, want, string(got), path)
+	}
+}
+
+func TestConfig_WatchSources(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"schema.sql":           `CREATE TABLE users (id INTEGER PRIMARY KEY);`,
+		"templates/a.txt.tmpl": `{{range .Schemas}}{{.Name}}{{end}}`,
+		"other/b.txt.tmpl":     `b`,
+		"schenerate.yaml": `
+sources:
+  sqlite: {driver: sqlite3, ddl: [schema.sql]}
+targets:
+  - {name: a, source: sqlite, templates: templates}
+  - {name: b, source: sqlite, templates: other}
+`,
+	})
+	c, err := config.Load(filepath.Join(dir, "schenerate.yaml"))
+	require.Nil(t, err)
+
+	_, err = c.WatchSources(false, "unknown")
+	require.NotNil(t, err)
+
+	sources, err := c.WatchSources(true, "a")
+	require.Nil(t, err)
+	fingerprint := func() []string {
+		var fps []string
+		for _, s := range sources {
+			fp, err := s(context.Background())
+			require.Nil(t, err)
+			fps = append(fps, fp)
+		}
+		return fps
+	}
+
+	fp := fingerprint()
+	writeFiles(t, dir, map[string]string{"other/b.txt.tmpl": `changed`})
+	require.Equal(t, fp, fingerprint())
+	writeFiles(t, dir, map[string]string{"templates/a.txt.tmpl": `changed`})
+	require.NotEqual(t, fp, fingerprint())
+	fp = fingerprint()
+	writeFiles(t, dir, map[string]string{"schema.sql": `CREATE TABLE items (id INTEGER PRIMARY KEY);`})
+	require.NotEqual(t, fp, fingerprint())
+}
@@ -0,0 +1,281 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"github.com/Jumpaku/schenerate/files"
+	"github.com/Jumpaku/schenerate/gotype"
+	"github.com/Jumpaku/schenerate/introspect"
+	"github.com/Jumpaku/schenerate/plugin"
+	"github.com/Jumpaku/schenerate/postgres"
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/Jumpaku/schenerate/script"
+	"github.com/Jumpaku/schenerate/spanner"
+	"github.com/Jumpaku/schenerate/sqlite3"
+	"github.com/Jumpaku/schenerate/tmplgen"
+	"github.com/Jumpaku/schenerate/watch"
+	"github.com/samber/lo"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Run executes the targets of the names in order, or all the targets if no name is given.
+func (c *Config) Run(ctx context.Context, targets ...string) error {
+	selected, err := c.selectTargets(targets)
+	if err != nil {
+		return err
+	}
+	for _, t := range selected {
+		if err := c.runTarget(ctx, t); err != nil {
+			return fmt.Errorf(`fail to run target %s: %w`, t.Name, err)
+		}
+	}
+	return nil
+}
+
+// WatchSources returns the sources of the inputs of the targets of the names, or of all the targets if no name is given,
+// which are the DDL files, the migrations, the templates, the plugins and the scripts.
+// If database is true, the schemas of the databases specified by DSN are also watched.
+func (c *Config) WatchSources(database bool, targets ...string) ([]watch.Source, error) {
+	selected, err := c.selectTargets(targets)
+	if err != nil {
+		return nil, err
+	}
+	var paths, dataSources []string
+	for _, t := range selected {
+		s := c.Sources[t.Source]
+		paths = append(paths, lo.Map(s.DDL, func(p string, _ int) string { return c.path(p) })...)
+		if s.Migrations != "" {
+			paths = append(paths, c.path(s.Migrations))
+		}
+		switch {
+		case t.Templates != "":
+			paths = append(paths, c.path(t.Templates))
+		case t.Script != nil:
+			paths = append(paths, c.path(t.Script.Path))
+		case t.Plugin.WASM != "":
+			paths = append(paths, c.path(t.Plugin.WASM))
+		case strings.ContainsRune(t.Plugin.Command, '/'):
+			paths = append(paths, c.path(t.Plugin.Command))
+		}
+		if database && len(s.DDL) == 0 && s.Migrations == "" && !lo.Contains(dataSources, t.Source) {
+			dataSources = append(dataSources, t.Source)
+		}
+	}
+
+	sources := []watch.Source{watch.Files(lo.Uniq(paths)...)}
+	for _, name := range dataSources {
+		s := c.Sources[name]
+		sources = append(sources, watch.Schemas(func(ctx context.Context) (schema.Schemas, error) {
+			in, err := introspect.Open(ctx, s.Driver, os.ExpandEnv(s.DSN))
+			if err != nil {
+				return nil, err
+			}
+			defer in.Close()
+			return introspect.ListSchemas(ctx, in, nil)
+		}))
+	}
+	return sources, nil
+}
+
+func (c *Config) selectTargets(names []string) ([]Target, error) {
+	for _, name := range names {
+		if !lo.ContainsBy(c.Targets, func(t Target) bool { return t.Name == name }) {
+			return nil, fmt.Errorf(`target %s not found`, name)
+		}
+	}
+	return lo.Filter(c.Targets, func(t Target, _ int) bool { return len(names) == 0 || lo.Contains(names, t.Name) }), nil
+}
+
+func (c *Config) runTarget(ctx context.Context, t Target) error {
+	source := c.Sources[t.Source]
+	generate, err := c.generator(ctx, source.Driver, t)
+	if err != nil {
+		return err
+	}
+
+	schemas, err := c.listSchemas(ctx, source, t.selector())
+	if err != nil {
+		return fmt.Errorf(`fail to list schemas from source %s: %w`, t.Source, err)
+	}
+
+	w := &files.Writer{}
+	if err := generate(w, schemas); err != nil {
+		return err
+	}
+	if err := w.SaveAll(); err != nil {
+		return fmt.Errorf(`fail to save files writer: %w`, err)
+	}
+
+	for _, p := range t.PostProcess {
+		cmd := exec.CommandContext(ctx, p.Command, append(append([]string{}, p.Args...), w.Paths()...)...)
+		cmd.Dir = c.dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf(`fail to post-process by %s: %w: %s`, p.Command, err, strings.TrimSpace(string(out)))
+		}
+	}
+	return nil
+}
+
+func (c *Config) generator(ctx context.Context, driver string, t Target) (schema.GeneratorWithSchema, error) {
+	if t.Script != nil {
+		g, err := script.Load(ctx, c.path(t.Script.Path), c.path(t.Out), script.WithParameters(t.Script.Parameters), script.WithPrint(c.stderr()))
+		if err != nil {
+			return nil, err
+		}
+		return g.Generate, nil
+	}
+	if t.Plugin != nil && t.Plugin.WASM != "" {
+		p := plugin.WASM{
+			Path:             c.path(t.Plugin.WASM),
+			SHA256:           t.Plugin.SHA256,
+			Parameters:       t.Plugin.Parameters,
+			Stderr:           c.Stderr,
+			MemoryLimitPages: t.Plugin.MemoryLimitPages,
+		}
+		if t.Plugin.Timeout != "" {
+			timeout, err := time.ParseDuration(t.Plugin.Timeout)
+			if err != nil {
+				return nil, fmt.Errorf(`fail to parse plugin timeout: %w`, err)
+			}
+			p.Timeout = timeout
+		}
+		return p.GeneratorWithSchema(ctx, driver, c.path(t.Out)), nil
+	}
+	if t.Plugin != nil {
+		p := plugin.Plugin{
+			Command:    t.Plugin.Command,
+			Args:       t.Plugin.Args,
+			Dir:        c.dir,
+			Parameters: t.Plugin.Parameters,
+			Stderr:     c.Stderr,
+		}
+		return p.GeneratorWithSchema(ctx, driver, c.path(t.Out)), nil
+	}
+	mapper, err := c.goTypeMapper(driver, t.Types)
+	if err != nil {
+		return nil, err
+	}
+	g, err := tmplgen.New(os.DirFS(c.path(t.Templates)), c.path(t.Out), tmplgen.WithFuncs(tmplgen.GoTypeFuncs(mapper)))
+	if err != nil {
+		return nil, fmt.Errorf(`fail to load templates: %w`, err)
+	}
+	return g.Generate, nil
+}
+
+func (c *Config) stderr() io.Writer {
+	if c.Stderr == nil {
+		return io.Discard
+	}
+	return c.Stderr
+}
+
+func (c *Config) listSchemas(ctx context.Context, s Source, selector schema.Selector) (schema.Schemas, error) {
+	switch {
+	case len(s.DDL) > 0:
+		ddls, err := files.ReadAll(lo.Map(s.DDL, func(p string, _ int) string { return c.path(p) })...)
+		if err != nil {
+			return nil, fmt.Errorf(`fail to read DDL files: %w`, err)
+		}
+		switch s.Driver {
+		case sqlite3.DriverName:
+			q, err := sqlite3.OpenDDL(ddls...)
+			if err != nil {
+				return nil, fmt.Errorf(`fail to open DDL: %w`, err)
+			}
+			defer q.Close()
+			return introspect.SelectSchemas(ctx, q, selector)
+		case spanner.DriverName:
+			schemas, err := spanner.ParseDDL(ddls...)
+			if err != nil {
+				return nil, fmt.Errorf(`fail to parse DDL: %w`, err)
+			}
+			return selector.SelectSchemas(schemas.Unified())
+		default:
+			return nil, fmt.Errorf(`ddl is not supported by driver %q`, s.Driver)
+		}
+	case s.Migrations != "":
+		dir := c.path(s.Migrations)
+		switch s.Driver {
+		case sqlite3.DriverName:
+			schemas, err := sqlite3.ListSchemasFromMigrations(ctx, dir, s.Version)
+			if err != nil {
+				return nil, err
+			}
+			return selector.SelectSchemas(schemas.Unified())
+		case spanner.DriverName:
+			schemas, err := spanner.ListSchemasFromMigrations(ctx, dir, s.Version)
+			if err != nil {
+				return nil, err
+			}
+			return selector.SelectSchemas(schemas.Unified())
+		case postgres.DriverName:
+			if s.DSN == "" {
+				return nil, fmt.Errorf(`migrations of postgres require dsn`)
+			}
+			q, err := postgres.Open(os.ExpandEnv(s.DSN))
+			if err != nil {
+				return nil, err
+			}
+			defer q.Close()
+			schemas, err := postgres.ListSchemasFromMigrations(ctx, q, dir, s.Version)
+			if err != nil {
+				return nil, err
+			}
+			return selector.SelectSchemas(schemas.Unified())
+		default:
+			return nil, fmt.Errorf(`migrations are not supported by driver %q`, s.Driver)
+		}
+	default:
+		in, err := introspect.Open(ctx, s.Driver, os.ExpandEnv(s.DSN))
+		if err != nil {
+			return nil, err
+		}
+		defer in.Close()
+		return introspect.SelectSchemas(ctx, in, selector)
+	}
+}
+
+// selector returns the selector of the tables, whose include patterns are Tables and Select.Include.
+func (t Target) selector() schema.Selector {
+	s := t.Select
+	s.Include = append(append([]string{}, t.Tables...), s.Include...)
+	return s
+}
+
+func (c *Config) goTypeMapper(driver string, types Types) (*gotype.Mapper, error) {
+	style := types.NullStyle
+	if style == "" {
+		style = gotype.NullStylePointer
+	}
+	var m *gotype.Mapper
+	switch driver {
+	case postgres.DriverName:
+		m = postgres.NewGoTypeMapper(style)
+	case sqlite3.DriverName:
+		m = sqlite3.NewGoTypeMapper(style)
+	case spanner.DriverName:
+		m = spanner.NewGoTypeMapper(style)
+	default:
+		m = gotype.NewMapper(nil, style)
+	}
+	for _, o := range types.Overrides {
+		mapping := gotype.Mapping{
+			Type: gotype.Type{Name: o.GoType, Import: o.Import},
+			Null: gotype.Type{Name: o.NullGoType, Import: o.NullImport},
+		}
+		if o.Type != "" {
+			m.OverrideType(o.Type, mapping)
+			continue
+		}
+		i := strings.LastIndex(o.Column, ".")
+		if i < 0 {
+			return nil, fmt.Errorf(`invalid column %q: <table>.<column> expected`, o.Column)
+		}
+		m.OverrideColumn(o.Column[:i], o.Column[i+1:], mapping)
+	}
+	return m, nil
+}
@@ -0,0 +1,248 @@
+// Package ddl renders DDL statements of schema objects in each dialect.
+// Statements are returned without trailing semicolons.
+package ddl
+
+import (
+	"fmt"
+	"github.com/Jumpaku/schenerate/name"
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/samber/lo"
+	"strings"
+)
+
+// Quote returns the identifier quoted in the dialect.
+func Quote(d schema.Dialect, ident string) string {
+	return name.Quote(name.Dialect(d), ident)
+}
+
+// TableName returns the quoted name of the table, which is qualified by the schema name in Postgres.
+func TableName(d schema.Dialect, t schema.Schema) string {
+	return qualify(d, t.Schema, t.Name)
+}
+
+func qualify(d schema.Dialect, namespace, table string) string {
+	if d == schema.DialectPostgres && namespace != "" {
+		return Quote(d, namespace) + "." + Quote(d, table)
+	}
+	return Quote(d, table)
+}
+
+// ColumnDefinition returns the definition of the column, e.g. "name" text NOT NULL.
+func ColumnDefinition(d schema.Dialect, c schema.Column) string {
+	def := Quote(d, c.Name) + " " + c.Type
+	if !c.Nullable {
+		def += " NOT NULL"
+	}
+	return def
+}
+
+// CreateTable returns a CREATE TABLE statement with the columns, the primary key, the foreign keys and the unique keys of the table.
+// In Spanner, unique keys are not included because they are created as unique indexes by AddUniqueKey.
+// Indexes are not included, which are created by CreateIndex.
+func CreateTable(d schema.Dialect, t schema.Schema) string {
+	defs := lo.Map(t.Columns, func(c schema.Column, _ int) string { return ColumnDefinition(d, c) })
+	if d != schema.DialectSpanner && len(t.PrimaryKey) > 0 {
+		defs = append(defs, constraintName(d, t.PrimaryKeyName)+"PRIMARY KEY "+columnList(d, t.PrimaryKey))
+	}
+	if d != schema.DialectSpanner {
+		for _, uk := range t.UniqueKeys {
+			defs = append(defs, constraintName(d, uk.Name)+"UNIQUE "+columnList(d, uk.Key))
+		}
+	}
+	for _, fk := range t.ForeignKeys {
+		defs = append(defs, foreignKeyDefinition(d, fk))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "CREATE TABLE %s (\n", TableName(d, t))
+	b.WriteString(strings.Join(lo.Map(defs, func(def string, _ int) string { return "  " + def }), ",\n"))
+	b.WriteString("\n)")
+	if d == schema.DialectSpanner {
+		b.WriteString(" PRIMARY KEY " + columnList(d, t.PrimaryKey))
+		if t.Parent != "" {
+			b.WriteString(",\n  INTERLEAVE IN PARENT " + Quote(d, t.Parent))
+		}
+	}
+	return b.String()
+}
+
+// DropTable returns a DROP TABLE statement.
+func DropTable(d schema.Dialect, t schema.Schema) string {
+	return "DROP TABLE " + TableName(d, t)
+}
+
+// AddColumn returns an ALTER TABLE statement adding the column.
+func AddColumn(d schema.Dialect, t schema.Schema, c schema.Column) string {
+	return alterTable(d, t, "ADD COLUMN "+ColumnDefinition(d, c))
+}
+
+// DropColumn returns an ALTER TABLE statement dropping the column.
+func DropColumn(d schema.Dialect, t schema.Schema, column string) string {
+	return alterTable(d, t, "DROP COLUMN "+Quote(d, column))
+}
+
+// AlterColumn returns ALTER TABLE statements changing the type and the nullability of the column from before to after.
+// It is not supported in SQLite3, whose tables must be rebuilt instead.
+func AlterColumn(d schema.Dialect, t schema.Schema, before, after schema.Column) ([]string, error) {
+	switch d {
+	case schema.DialectPostgres:
+		var stmts []string
+		if before.Type != after.Type {
+			stmts = append(stmts, alterTable(d, t, fmt.Sprintf(`ALTER COLUMN %s TYPE %s`, Quote(d, after.Name), after.Type)))
+		}
+		if before.Nullable != after.Nullable {
+			action := "SET NOT NULL"
+			if after.Nullable {
+				action = "DROP NOT NULL"
+			}
+			stmts = append(stmts, alterTable(d, t, fmt.Sprintf(`ALTER COLUMN %s %s`, Quote(d, after.Name), action)))
+		}
+		return stmts, nil
+	case schema.DialectSpanner:
+		return []string{alterTable(d, t, "ALTER COLUMN "+ColumnDefinition(d, after))}, nil
+	default:
+		return nil, fmt.Errorf(`fail to alter column %s: unsupported in %s`, after.Name, d)
+	}
+}
+
+// AddPrimaryKey returns an ALTER TABLE statement adding the primary key, which is supported only in Postgres.
+func AddPrimaryKey(d schema.Dialect, t schema.Schema) (string, error) {
+	if d != schema.DialectPostgres {
+		return "", fmt.Errorf(`fail to add primary key to %s: unsupported in %s`, t.Name, d)
+	}
+	return alterTable(d, t, "ADD "+constraintName(d, t.PrimaryKeyName)+"PRIMARY KEY "+columnList(d, t.PrimaryKey)), nil
+}
+
+// DropPrimaryKey returns an ALTER TABLE statement dropping the primary key constraint named PrimaryKeyName,
+// which is supported only in Postgres. It fails if the name is unknown.
+func DropPrimaryKey(d schema.Dialect, t schema.Schema) (string, error) {
+	if d != schema.DialectPostgres {
+		return "", fmt.Errorf(`fail to drop primary key of %s: unsupported in %s`, t.Name, d)
+	}
+	if t.PrimaryKeyName == "" {
+		return "", fmt.Errorf(`fail to drop primary key of %s: constraint name unknown`, t.Name)
+	}
+	return alterTable(d, t, "DROP CONSTRAINT "+Quote(d, t.PrimaryKeyName)), nil
+}
+
+// AddForeignKey returns an ALTER TABLE statement adding the foreign key, which is not supported in SQLite3.
+func AddForeignKey(d schema.Dialect, t schema.Schema, fk schema.ForeignKey) (string, error) {
+	if d == schema.DialectSQLite3 {
+		return "", fmt.Errorf(`fail to add foreign key to %s: unsupported in %s`, t.Name, d)
+	}
+	return alterTable(d, t, "ADD "+foreignKeyDefinition(d, fk)), nil
+}
+
+// DropForeignKey returns an ALTER TABLE statement dropping the foreign key, which is not supported in SQLite3.
+func DropForeignKey(d schema.Dialect, t schema.Schema, fk schema.ForeignKey) (string, error) {
+	if d == schema.DialectSQLite3 || fk.Name == "" {
+		return "", fmt.Errorf(`fail to drop foreign key of %s: unsupported in %s`, t.Name, d)
+	}
+	return alterTable(d, t, "DROP CONSTRAINT "+Quote(d, fk.Name)), nil
+}
+
+// AddUniqueKey returns a statement adding the unique key, which is a unique index in Spanner. It is not supported in SQLite3.
+func AddUniqueKey(d schema.Dialect, t schema.Schema, uk schema.UniqueKey) (string, error) {
+	switch d {
+	case schema.DialectPostgres:
+		return alterTable(d, t, "ADD "+constraintName(d, uk.Name)+"UNIQUE "+columnList(d, uk.Key)), nil
+	case schema.DialectSpanner:
+		return CreateIndex(d, t, schema.Index{Name: uk.Name, Unique: true, Key: lo.Map(uk.Key, func(k string, _ int) schema.IndexKeyElem {
+			return schema.IndexKeyElem{Name: k}
+		})}), nil
+	default:
+		return "", fmt.Errorf(`fail to add unique key to %s: unsupported in %s`, t.Name, d)
+	}
+}
+
+// DropUniqueKey returns a statement dropping the unique key, which is a unique index in Spanner. It is not supported in SQLite3.
+func DropUniqueKey(d schema.Dialect, t schema.Schema, uk schema.UniqueKey) (string, error) {
+	switch d {
+	case schema.DialectPostgres:
+		return alterTable(d, t, "DROP CONSTRAINT "+Quote(d, uk.Name)), nil
+	case schema.DialectSpanner:
+		return DropIndex(d, t, schema.Index{Name: uk.Name}), nil
+	default:
+		return "", fmt.Errorf(`fail to drop unique key of %s: unsupported in %s`, t.Name, d)
+	}
+}
+
+// CreateIndex returns a CREATE INDEX statement.
+func CreateIndex(d schema.Dialect, t schema.Schema, idx schema.Index) string {
+	unique := ""
+	if idx.Unique {
+		unique = "UNIQUE "
+	}
+	key := lo.Map(idx.Key, func(e schema.IndexKeyElem, _ int) string {
+		if e.Desc {
+			return Quote(d, e.Name) + " DESC"
+		}
+		return Quote(d, e.Name)
+	})
+	return fmt.Sprintf(`CREATE %sINDEX %s ON %s (%s)`, unique, Quote(d, idx.Name), TableName(d, t), strings.Join(key, ", "))
+}
+
+// DropIndex returns a DROP INDEX statement.
+func DropIndex(d schema.Dialect, t schema.Schema, idx schema.Index) string {
+	return "DROP INDEX " + qualify(d, t.Schema, idx.Name)
+}
+
+// Indexes returns the indexes of the table that are created by CREATE INDEX statements,
+// excluding the indexes created by PRIMARY KEY and UNIQUE constraints in SQLite3 and the indexes of unique constraints in Postgres.
+func Indexes(d schema.Dialect, t schema.Schema) []schema.Index {
+	return lo.Filter(t.Indexes, func(idx schema.Index, _ int) bool {
+		switch d {
+		case schema.DialectSQLite3:
+			return idx.Origin == "" || idx.Origin == "c"
+		case schema.DialectPostgres:
+			return !lo.ContainsBy(t.UniqueKeys, func(uk schema.UniqueKey) bool { return uk.Name == idx.Name })
+		default:
+			return true
+		}
+	})
+}
+
+func alterTable(d schema.Dialect, t schema.Schema, action string) string {
+	return "ALTER TABLE " + TableName(d, t) + " " + action
+}
+
+func columnList(d schema.Dialect, columns []string) string {
+	return "(" + strings.Join(lo.Map(columns, func(c string, _ int) string { return Quote(d, c) }), ", ") + ")"
+}
+
+// constraintName returns the CONSTRAINT clause, which is omitted if the name is empty or reserved by SQLite3.
+func constraintName(d schema.Dialect, constraint string) string {
+	if constraint == "" || (d == schema.DialectSQLite3 && strings.HasPrefix(strings.ToLower(constraint), "sqlite_")) {
+		return ""
+	}
+	return "CONSTRAINT " + Quote(d, constraint) + " "
+}
+
+func foreignKeyDefinition(d schema.Dialect, fk schema.ForeignKey) string {
+	return fmt.Sprintf(`%sFOREIGN KEY %s REFERENCES %s %s`,
+		constraintName(d, fk.Name), columnList(d, fk.Key), qualify(d, fk.Reference.Schema, fk.Reference.Table), columnList(d, fk.Reference.Key))
+}
+
+// RenameTable returns an ALTER TABLE statement renaming the table, which is not supported in Spanner.
+func RenameTable(d schema.Dialect, t schema.Schema, newName string) (string, error) {
+	if d == schema.DialectSpanner {
+		return "", fmt.Errorf(`fail to rename table %s: unsupported in %s`, t.Name, d)
+	}
+	return alterTable(d, t, "RENAME TO "+Quote(d, newName)), nil
+}
+
+// CreationOrder returns the indexes of the schemas in the order in which the tables can be created, where referenced and parent tables precede.
+// If foreign keys are cyclic, only interleaving is considered and cyclic is true.
+func CreationOrder(schemas schema.Schemas) (order []int, cyclic bool) {
+	if order, cyclic := schemas.BuildGraph().TopologicalSort(); !cyclic {
+		return order, false
+	}
+	parents := schema.Schemas(lo.Map(schemas, func(s schema.Schema, _ int) schema.Schema {
+		s.ForeignKeys = nil
+		return s
+	}))
+	if order, cyclic := parents.BuildGraph().TopologicalSort(); !cyclic {
+		return order, true
+	}
+	return lo.Range(len(schemas)), true
+}
@@ -0,0 +1,145 @@
+package ddl_test
+
+import (
+	"github.com/Jumpaku/schenerate/ddl"
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func table(d schema.Dialect) schema.Schema {
+	s := schema.Schema{
+		Dialect: d,
+		Name:    "Users",
+		Columns: []schema.Column{
+			{Name: "ID", Type: "INT64"},
+			{Name: "GroupID", Type: "INT64", Nullable: true},
+		},
+		PrimaryKey: []string{"ID"},
+		ForeignKeys: []schema.ForeignKey{
+			{Name: "FK_Users_Groups", Key: []string{"GroupID"}, Reference: schema.ForeignKeyReference{Table: "Groups", Key: []string{"ID"}}},
+		},
+		UniqueKeys: []schema.UniqueKey{{Name: "UQ_Users_GroupID", Key: []string{"GroupID"}}},
+	}
+	if d == schema.DialectPostgres {
+		s.Schema = "public"
+		s.ForeignKeys[0].Reference.Schema = "public"
+	}
+	return s
+}
+
+func TestCreateTable(t *testing.T) {
+	testcases := []struct {
+		dialect schema.Dialect
+		in      func(s schema.Schema) schema.Schema
+		want    string
+	}{
+		{
+			dialect: schema.DialectPostgres,
+			in:      func(s schema.Schema) schema.Schema { return s },
+			want: `CREATE TABLE "public"."Users" (
+  "ID" INT64 NOT NULL,
+  "GroupID" INT64,
+  PRIMARY KEY ("ID"),
+  CONSTRAINT "UQ_Users_GroupID" UNIQUE ("GroupID"),
+  CONSTRAINT "FK_Users_Groups" FOREIGN KEY ("GroupID") REFERENCES "public"."Groups" ("ID")
+)`,
+		},
+		{
+			dialect: schema.DialectSQLite3,
+			in: func(s schema.Schema) schema.Schema {
+				s.UniqueKeys[0].Name = "sqlite_autoindex_Users_1"
+				s.ForeignKeys[0].Name = ""
+				return s
+			},
+			want: `CREATE TABLE [Users] (
+  [ID] INT64 NOT NULL,
+  [GroupID] INT64,
+  PRIMARY KEY ([ID]),
+  UNIQUE ([GroupID]),
+  FOREIGN KEY ([GroupID]) REFERENCES [Groups] ([ID])
+)`,
+		},
+		{
+			dialect: schema.DialectSpanner,
+			in: func(s schema.Schema) schema.Schema {
+				s.Parent = "Groups"
+				s.ForeignKeys = nil
+				return s
+			},
+			want: "CREATE TABLE `Users` (\n" +
+				"  `ID` INT64 NOT NULL,\n" +
+				"  `GroupID` INT64\n" +
+				") PRIMARY KEY (`ID`),\n" +
+				"  INTERLEAVE IN PARENT `Groups`",
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(string(testcase.dialect), func(t *testing.T) {
+			got := ddl.CreateTable(testcase.dialect, testcase.in(table(testcase.dialect)))
+			require.Equal(t, testcase.want, got)
+		})
+	}
+}
+
+func TestStatements(t *testing.T) {
+	pg, sp := table(schema.DialectPostgres), table(schema.DialectSpanner)
+	idx := schema.Index{Name: "IDX", Unique: true, Key: []schema.IndexKeyElem{{Name: "GroupID", Desc: true}, {Name: "ID"}}}
+
+	require.Equal(t, `CREATE UNIQUE INDEX "IDX" ON "public"."Users" ("GroupID" DESC, "ID")`, ddl.CreateIndex(schema.DialectPostgres, pg, idx))
+	require.Equal(t, `DROP INDEX "public"."IDX"`, ddl.DropIndex(schema.DialectPostgres, pg, idx))
+	require.Equal(t, "DROP TABLE `Users`", ddl.DropTable(schema.DialectSpanner, sp))
+	require.Equal(t, "ALTER TABLE `Users` ADD COLUMN `Name` STRING(MAX) NOT NULL", ddl.AddColumn(schema.DialectSpanner, sp, schema.Column{Name: "Name", Type: "STRING(MAX)"}))
+	require.Equal(t, `ALTER TABLE "public"."Users" DROP COLUMN "GroupID"`, ddl.DropColumn(schema.DialectPostgres, pg, "GroupID"))
+
+	stmts, err := ddl.AlterColumn(schema.DialectPostgres, pg, schema.Column{Name: "GroupID", Type: "integer", Nullable: true}, schema.Column{Name: "GroupID", Type: "bigint"})
+	require.Nil(t, err)
+	require.Equal(t, []string{
+		`ALTER TABLE "public"."Users" ALTER COLUMN "GroupID" TYPE bigint`,
+		`ALTER TABLE "public"."Users" ALTER COLUMN "GroupID" SET NOT NULL`,
+	}, stmts)
+	stmts, err = ddl.AlterColumn(schema.DialectSpanner, sp, schema.Column{Name: "GroupID", Type: "INT64", Nullable: true}, schema.Column{Name: "GroupID", Type: "INT64"})
+	require.Nil(t, err)
+	require.Equal(t, []string{"ALTER TABLE `Users` ALTER COLUMN `GroupID` INT64 NOT NULL"}, stmts)
+	_, err = ddl.AlterColumn(schema.DialectSQLite3, table(schema.DialectSQLite3), schema.Column{}, schema.Column{})
+	require.NotNil(t, err)
+
+	stmt, err := ddl.AddForeignKey(schema.DialectSpanner, sp, sp.ForeignKeys[0])
+	require.Nil(t, err)
+	require.Equal(t, "ALTER TABLE `Users` ADD CONSTRAINT `FK_Users_Groups` FOREIGN KEY (`GroupID`) REFERENCES `Groups` (`ID`)", stmt)
+	stmt, err = ddl.AddUniqueKey(schema.DialectSpanner, sp, sp.UniqueKeys[0])
+	require.Nil(t, err)
+	require.Equal(t, "CREATE UNIQUE INDEX `UQ_Users_GroupID` ON `Users` (`GroupID`)", stmt)
+	_, err = ddl.DropPrimaryKey(schema.DialectPostgres, pg)
+	require.NotNil(t, err)
+	pg.PrimaryKeyName = "Users_pk"
+	stmt, err = ddl.DropPrimaryKey(schema.DialectPostgres, pg)
+	require.Nil(t, err)
+	require.Equal(t, `ALTER TABLE "public"."Users" DROP CONSTRAINT "Users_pk"`, stmt)
+	stmt, err = ddl.AddPrimaryKey(schema.DialectPostgres, pg)
+	require.Nil(t, err)
+	require.Equal(t, `ALTER TABLE "public"."Users" ADD CONSTRAINT "Users_pk" PRIMARY KEY ("ID")`, stmt)
+}
+
+func TestIndexes(t *testing.T) {
+	sq := table(schema.DialectSQLite3)
+	sq.Indexes = []schema.Index{{Name: "sqlite_autoindex_Users_1", Origin: "pk"}, {Name: "sqlite_autoindex_Users_2", Origin: "u"}, {Name: "IDX", Origin: "c"}}
+	require.Equal(t, []schema.Index{{Name: "IDX", Origin: "c"}}, ddl.Indexes(schema.DialectSQLite3, sq))
+
+	pg := table(schema.DialectPostgres)
+	pg.Indexes = []schema.Index{{Name: "UQ_Users_GroupID", Unique: true}, {Name: "IDX"}}
+	require.Equal(t, []schema.Index{{Name: "IDX"}}, ddl.Indexes(schema.DialectPostgres, pg))
+}
+
+func TestCreationOrder(t *testing.T) {
+	ref := func(table string) []schema.ForeignKey {
+		return []schema.ForeignKey{{Reference: schema.ForeignKeyReference{Table: table}}}
+	}
+	got, cyclic := ddl.CreationOrder(schema.Schemas{{Name: "C", ForeignKeys: ref("B")}, {Name: "B", ForeignKeys: ref("A")}, {Name: "A"}})
+	require.False(t, cyclic)
+	require.Equal(t, []int{2, 1, 0}, got)
+
+	got, cyclic = ddl.CreationOrder(schema.Schemas{{Name: "C", Parent: "B", ForeignKeys: ref("A")}, {Name: "B", ForeignKeys: ref("C")}, {Name: "A", ForeignKeys: ref("B")}})
+	require.True(t, cyclic)
+	require.Equal(t, []int{1, 2, 0}, got)
+}
@@ -0,0 +1,79 @@
+package ddl
+
+import (
+	"github.com/Jumpaku/schenerate/schema"
+	"strings"
+)
+
+// Render returns the statements creating the tables of the schemas with their constraints and indexes.
+// Tables are created in the order of BuildGraph().TopologicalSort(), so that foreign keys are defined inline.
+// If foreign keys are cyclic, tables are created in the order of interleaving, and the foreign keys referencing tables that are not created yet are added by later ALTER TABLE statements,
+// except in SQLite3, where foreign keys are always defined inline because referenced tables need not exist.
+// Views are not rendered.
+func Render(schemas schema.Schemas) ([]string, error) {
+	type key struct {
+		schema string
+		table  string
+	}
+	var tables, later, indexes []string
+	created := map[key]bool{}
+	order, _ := CreationOrder(schemas)
+	for _, i := range order {
+		t := schemas[i]
+		if !isTable(t) {
+			continue
+		}
+		d := t.Dialect
+		created[key{schema: t.Schema, table: t.Name}] = true
+
+		table := t
+		table.ForeignKeys = nil
+		for _, fk := range t.ForeignKeys {
+			if d == schema.DialectSQLite3 || created[key{schema: fk.Reference.Schema, table: fk.Reference.Table}] {
+				table.ForeignKeys = append(table.ForeignKeys, fk)
+				continue
+			}
+			stmt, err := AddForeignKey(d, t, fk)
+			if err != nil {
+				return nil, err
+			}
+			later = append(later, stmt)
+		}
+		tables = append(tables, CreateTable(d, table))
+
+		if d == schema.DialectSpanner {
+			for _, uk := range t.UniqueKeys {
+				stmt, err := AddUniqueKey(d, t, uk)
+				if err != nil {
+					return nil, err
+				}
+				indexes = append(indexes, stmt)
+			}
+		}
+		for _, idx := range Indexes(d, t) {
+			indexes = append(indexes, CreateIndex(d, t, idx))
+		}
+	}
+	return append(append(tables, later...), indexes...), nil
+}
+
+// Join joins the statements into a script, each of which is terminated by a semicolon.
+func Join(stmts []string) string {
+	var b strings.Builder
+	for i, stmt := range stmts {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(stmt + ";\n")
+	}
+	return b.String()
+}
+
+func isTable(t schema.Schema) bool {
+	switch strings.ToUpper(t.Type) {
+	case "VIEW":
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,77 @@
+package ddl_test
+
+import (
+	"github.com/Jumpaku/schenerate/ddl"
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	pg := func(name, ref string) schema.Schema {
+		return schema.Schema{
+			Dialect: schema.DialectPostgres, Schema: "public", Name: name, Type: "BASE TABLE",
+			Columns:    []schema.Column{{Name: "id", Type: "integer"}},
+			PrimaryKey: []string{"id"},
+			ForeignKeys: []schema.ForeignKey{
+				{Name: "fk_" + name, Key: []string{"id"}, Reference: schema.ForeignKeyReference{Schema: "public", Table: ref, Key: []string{"id"}}},
+			},
+		}
+	}
+	sp := func(name, parent string) schema.Schema {
+		return schema.Schema{
+			Dialect: schema.DialectSpanner, Name: name, Type: "BASE TABLE", Parent: parent,
+			Columns:    []schema.Column{{Name: "ID", Type: "INT64"}},
+			PrimaryKey: []string{"ID"},
+		}
+	}
+	testcases := []struct {
+		name string
+		in   schema.Schemas
+		want []string
+	}{
+		{
+			name: "acyclic foreign keys",
+			in:   schema.Schemas{pg("b", "a"), {Dialect: schema.DialectPostgres, Schema: "public", Name: "a", Columns: []schema.Column{{Name: "id", Type: "integer"}}}},
+			want: []string{
+				"CREATE TABLE \"public\".\"a\" (\n  \"id\" integer NOT NULL\n)",
+				"CREATE TABLE \"public\".\"b\" (\n  \"id\" integer NOT NULL,\n  PRIMARY KEY (\"id\"),\n  CONSTRAINT \"fk_b\" FOREIGN KEY (\"id\") REFERENCES \"public\".\"a\" (\"id\")\n)",
+			},
+		},
+		{
+			name: "cyclic foreign keys",
+			in:   schema.Schemas{pg("a", "b"), pg("b", "a"), pg("c", "c")},
+			want: []string{
+				"CREATE TABLE \"public\".\"a\" (\n  \"id\" integer NOT NULL,\n  PRIMARY KEY (\"id\")\n)",
+				"CREATE TABLE \"public\".\"b\" (\n  \"id\" integer NOT NULL,\n  PRIMARY KEY (\"id\"),\n  CONSTRAINT \"fk_b\" FOREIGN KEY (\"id\") REFERENCES \"public\".\"a\" (\"id\")\n)",
+				"CREATE TABLE \"public\".\"c\" (\n  \"id\" integer NOT NULL,\n  PRIMARY KEY (\"id\"),\n  CONSTRAINT \"fk_c\" FOREIGN KEY (\"id\") REFERENCES \"public\".\"c\" (\"id\")\n)",
+				`ALTER TABLE "public"."a" ADD CONSTRAINT "fk_a" FOREIGN KEY ("id") REFERENCES "public"."b" ("id")`,
+			},
+		},
+		{
+			name: "interleave",
+			in: func() schema.Schemas {
+				child := sp("Child", "Parent")
+				child.Indexes = []schema.Index{{Name: "IDX_Child", Key: []schema.IndexKeyElem{{Name: "ID", Desc: true}}}}
+				return schema.Schemas{child, sp("Parent", ""), {Dialect: schema.DialectSpanner, Name: "V", Type: "VIEW"}}
+			}(),
+			want: []string{
+				"CREATE TABLE `Parent` (\n  `ID` INT64 NOT NULL\n) PRIMARY KEY (`ID`)",
+				"CREATE TABLE `Child` (\n  `ID` INT64 NOT NULL\n) PRIMARY KEY (`ID`),\n  INTERLEAVE IN PARENT `Parent`",
+				"CREATE INDEX `IDX_Child` ON `Child` (`ID` DESC)",
+			},
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			got, err := ddl.Render(testcase.in)
+
+			require.Nil(t, err)
+			require.Equal(t, testcase.want, got)
+		})
+	}
+}
+
+func TestJoin(t *testing.T) {
+	require.Equal(t, "CREATE TABLE a (x INT);\n\nCREATE TABLE b (x INT);\n", ddl.Join([]string{"CREATE TABLE a (x INT)", "CREATE TABLE b (x INT)"}))
+}
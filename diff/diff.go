@@ -0,0 +1,236 @@
+package diff
+
+import (
+	"fmt"
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/samber/lo"
+	"slices"
+	"strings"
+)
+
+// Kind is a kind of change.
+type Kind string
+
+const (
+	KindAdded    Kind = "added"
+	KindRemoved  Kind = "removed"
+	KindModified Kind = "modified"
+)
+
+// Object is a kind of schema object that is changed.
+type Object string
+
+const (
+	ObjectTable      Object = "table"
+	ObjectColumn     Object = "column"
+	ObjectPrimaryKey Object = "primary key"
+	ObjectForeignKey Object = "foreign key"
+	ObjectUniqueKey  Object = "unique key"
+	ObjectIndex      Object = "index"
+)
+
+// Change is a change of a schema object.
+type Change struct {
+	Kind   Kind   `json:"kind"`
+	Object Object `json:"object"`
+	// Schema and Table are the table of the object.
+	Schema string `json:"schema,omitempty"`
+	Table  string `json:"table"`
+	// Name is the name of the object, which is empty for tables, primary keys and unnamed constraints.
+	Name string `json:"name,omitempty"`
+	// Old and New are the definitions of the object before and after the change, which are empty if the object does not exist.
+	Old string `json:"old,omitempty"`
+	New string `json:"new,omitempty"`
+	// Details describes the modified properties, e.g. "type: STRING(50) -> STRING(100)".
+	Details []string `json:"details,omitempty"`
+}
+
+// QualifiedTable returns the table name qualified by the schema name if any.
+func (c Change) QualifiedTable() string {
+	if c.Schema == "" {
+		return c.Table
+	}
+	return c.Schema + "." + c.Table
+}
+
+type Changes []Change
+
+// Compare compares the schemas before and after and returns the changes, which are sorted by the tables.
+// Both schemas must be of the same dialect.
+func Compare(before, after schema.Schemas) (Changes, error) {
+	dialects := lo.Uniq(lo.Map(append(slices.Clone(before), after...), func(s schema.Schema, _ int) schema.Dialect { return s.Dialect }))
+	if len(dialects) > 1 {
+		return nil, fmt.Errorf(`fail to compare schemas of different dialects: %v`, dialects)
+	}
+
+	type key struct {
+		schema string
+		table  string
+	}
+	keys := lo.Uniq(lo.Map(append(slices.Clone(before), after...), func(s schema.Schema, _ int) key { return key{schema: s.Schema, table: s.Name} }))
+	slices.SortFunc(keys, func(a, b key) int {
+		if c := strings.Compare(a.schema, b.schema); c != 0 {
+			return c
+		}
+		return strings.Compare(a.table, b.table)
+	})
+
+	var changes Changes
+	for _, k := range keys {
+		b, inBefore := before.Find(k.schema, k.table)
+		a, inAfter := after.Find(k.schema, k.table)
+		switch {
+		case !inAfter:
+			changes = append(changes, Change{Kind: KindRemoved, Object: ObjectTable, Schema: k.schema, Table: k.table, Old: b.Type})
+		case !inBefore:
+			changes = append(changes, Change{Kind: KindAdded, Object: ObjectTable, Schema: k.schema, Table: k.table, New: a.Type})
+		default:
+			changes = append(changes, compareTable(b, a)...)
+		}
+	}
+	return changes, nil
+}
+
+func compareTable(before, after schema.Schema) Changes {
+	c := tableChanges{schema: after.Schema, table: after.Name}
+
+	var details []string
+	details = appendDetail(details, "type", before.Type, after.Type)
+	details = appendDetail(details, "parent", before.Parent, after.Parent)
+	if len(details) > 0 {
+		c.add(Change{Kind: KindModified, Object: ObjectTable, Old: before.Type, New: after.Type, Details: details})
+	}
+
+	compareNamed(&c, ObjectColumn, before.Columns, after.Columns,
+		func(col schema.Column) string { return col.Name },
+		formatColumn,
+		func(b, a schema.Column) []string {
+			var details []string
+			details = appendDetail(details, "type", b.Type, a.Type)
+			details = appendDetail(details, "nullable", fmt.Sprint(b.Nullable), fmt.Sprint(a.Nullable))
+			return details
+		})
+
+	switch b, a := formatKey(before.PrimaryKey), formatKey(after.PrimaryKey); {
+	case len(before.PrimaryKey) == 0 && len(after.PrimaryKey) > 0:
+		c.add(Change{Kind: KindAdded, Object: ObjectPrimaryKey, New: a})
+	case len(before.PrimaryKey) > 0 && len(after.PrimaryKey) == 0:
+		c.add(Change{Kind: KindRemoved, Object: ObjectPrimaryKey, Old: b})
+	case b != a:
+		c.add(Change{Kind: KindModified, Object: ObjectPrimaryKey, Old: b, New: a, Details: []string{"key: " + b + " -> " + a}})
+	}
+
+	compareConstraints(&c, ObjectForeignKey, before.ForeignKeys, after.ForeignKeys,
+		func(fk schema.ForeignKey) string { return fk.Name }, formatForeignKey)
+	compareConstraints(&c, ObjectUniqueKey, before.UniqueKeys, after.UniqueKeys,
+		func(uk schema.UniqueKey) string { return uk.Name }, func(uk schema.UniqueKey) string { return formatKey(uk.Key) })
+	compareNamed(&c, ObjectIndex, before.Indexes, after.Indexes,
+		func(idx schema.Index) string { return idx.Name },
+		formatIndex,
+		func(b, a schema.Index) []string {
+			var details []string
+			details = appendDetail(details, "unique", fmt.Sprint(b.Unique), fmt.Sprint(a.Unique))
+			details = appendDetail(details, "key", formatIndexKey(b.Key), formatIndexKey(a.Key))
+			details = appendDetail(details, "origin", b.Origin, a.Origin)
+			return details
+		})
+
+	return c.changes
+}
+
+type tableChanges struct {
+	schema  string
+	table   string
+	changes Changes
+}
+
+func (c *tableChanges) add(change Change) {
+	change.Schema, change.Table = c.schema, c.table
+	c.changes = append(c.changes, change)
+}
+
+// compareNamed compares objects identified by their names. Removed and modified objects are reported in the order of before, and then added objects in the order of after.
+func compareNamed[T any](c *tableChanges, object Object, before, after []T, name func(T) string, format func(T) string, details func(b, a T) []string) {
+	for _, b := range before {
+		a, ok := lo.Find(after, func(a T) bool { return name(a) == name(b) })
+		if !ok {
+			c.add(Change{Kind: KindRemoved, Object: object, Name: name(b), Old: format(b)})
+			continue
+		}
+		if d := details(b, a); len(d) > 0 {
+			c.add(Change{Kind: KindModified, Object: object, Name: name(b), Old: format(b), New: format(a), Details: d})
+		}
+	}
+	for _, a := range after {
+		if !lo.ContainsBy(before, func(b T) bool { return name(a) == name(b) }) {
+			c.add(Change{Kind: KindAdded, Object: object, Name: name(a), New: format(a)})
+		}
+	}
+}
+
+// compareConstraints compares constraints identified by their names if both are named, or by their definitions otherwise, e.g. foreign keys in SQLite3.
+func compareConstraints[T any](c *tableChanges, object Object, before, after []T, name func(T) string, format func(T) string) {
+	same := func(b, a T) bool {
+		if name(b) != "" && name(a) != "" {
+			return name(b) == name(a)
+		}
+		return format(b) == format(a)
+	}
+	for _, b := range before {
+		a, ok := lo.Find(after, func(a T) bool { return same(b, a) })
+		if !ok {
+			c.add(Change{Kind: KindRemoved, Object: object, Name: name(b), Old: format(b)})
+			continue
+		}
+		if format(b) != format(a) {
+			c.add(Change{Kind: KindModified, Object: object, Name: name(b), Old: format(b), New: format(a), Details: []string{"definition: " + format(b) + " -> " + format(a)}})
+		}
+	}
+	for _, a := range after {
+		if !lo.ContainsBy(before, func(b T) bool { return same(b, a) }) {
+			c.add(Change{Kind: KindAdded, Object: object, Name: name(a), New: format(a)})
+		}
+	}
+}
+
+func appendDetail(details []string, property, before, after string) []string {
+	if before == after {
+		return details
+	}
+	return append(details, fmt.Sprintf(`%s: %s -> %s`, property, before, after))
+}
+
+func formatColumn(c schema.Column) string {
+	if c.Nullable {
+		return c.Type
+	}
+	return c.Type + " NOT NULL"
+}
+
+func formatKey(key []string) string {
+	return "(" + strings.Join(key, ", ") + ")"
+}
+
+func formatForeignKey(fk schema.ForeignKey) string {
+	table := fk.Reference.Table
+	if fk.Reference.Schema != "" {
+		table = fk.Reference.Schema + "." + table
+	}
+	return formatKey(fk.Key) + " REFERENCES " + table + " " + formatKey(fk.Reference.Key)
+}
+
+func formatIndex(idx schema.Index) string {
+	if idx.Unique {
+		return "UNIQUE " + formatIndexKey(idx.Key)
+	}
+	return formatIndexKey(idx.Key)
+}
+
+func formatIndexKey(key []schema.IndexKeyElem) string {
+	return formatKey(lo.Map(key, func(e schema.IndexKeyElem, _ int) string {
+		if e.Desc {
+			return e.Name + " DESC"
+		}
+		return e.Name
+	}))
+}
@@ -0,0 +1,133 @@
+package diff_test
+
+import (
+	"github.com/Jumpaku/schenerate/diff"
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func users() schema.Schema {
+	return schema.Schema{
+		Dialect: schema.DialectSpanner,
+		Name:    "Users",
+		Type:    "BASE TABLE",
+		Columns: []schema.Column{
+			{Name: "ID", Type: "STRING(36)"},
+			{Name: "Name", Type: "STRING(50)"},
+			{Name: "Note", Type: "STRING(MAX)", Nullable: true},
+		},
+		PrimaryKey: []string{"ID"},
+		Indexes: []schema.Index{
+			{Name: "IDX_Users_Name", Key: []schema.IndexKeyElem{{Name: "Name"}}},
+		},
+	}
+}
+
+func TestCompare(t *testing.T) {
+	testcases := []struct {
+		name   string
+		before schema.Schemas
+		after  func(s schema.Schema) schema.Schemas
+		want   diff.Changes
+	}{
+		{
+			name:   "no change",
+			before: schema.Schemas{users()},
+			after:  func(s schema.Schema) schema.Schemas { return schema.Schemas{s} },
+			want:   nil,
+		},
+		{
+			name:   "table added",
+			before: schema.Schemas{users()},
+			after: func(s schema.Schema) schema.Schemas {
+				return schema.Schemas{s, {Dialect: schema.DialectSpanner, Name: "Items", Type: "BASE TABLE"}}
+			},
+			want: diff.Changes{
+				{Kind: diff.KindAdded, Object: diff.ObjectTable, Table: "Items", New: "BASE TABLE"},
+			},
+		},
+		{
+			name:   "table removed",
+			before: schema.Schemas{users()},
+			after:  func(s schema.Schema) schema.Schemas { return schema.Schemas{} },
+			want: diff.Changes{
+				{Kind: diff.KindRemoved, Object: diff.ObjectTable, Table: "Users", Old: "BASE TABLE"},
+			},
+		},
+		{
+			name:   "columns",
+			before: schema.Schemas{users()},
+			after: func(s schema.Schema) schema.Schemas {
+				s.Columns = []schema.Column{
+					{Name: "ID", Type: "STRING(36)"},
+					{Name: "Name", Type: "STRING(100)", Nullable: true},
+					{Name: "Email", Type: "STRING(100)"},
+				}
+				return schema.Schemas{s}
+			},
+			want: diff.Changes{
+				{Kind: diff.KindModified, Object: diff.ObjectColumn, Table: "Users", Name: "Name", Old: "STRING(50) NOT NULL", New: "STRING(100)", Details: []string{"type: STRING(50) -> STRING(100)", "nullable: false -> true"}},
+				{Kind: diff.KindRemoved, Object: diff.ObjectColumn, Table: "Users", Name: "Note", Old: "STRING(MAX)"},
+				{Kind: diff.KindAdded, Object: diff.ObjectColumn, Table: "Users", Name: "Email", New: "STRING(100) NOT NULL"},
+			},
+		},
+		{
+			name:   "primary key",
+			before: schema.Schemas{users()},
+			after: func(s schema.Schema) schema.Schemas {
+				s.PrimaryKey = []string{"ID", "Name"}
+				return schema.Schemas{s}
+			},
+			want: diff.Changes{
+				{Kind: diff.KindModified, Object: diff.ObjectPrimaryKey, Table: "Users", Old: "(ID)", New: "(ID, Name)", Details: []string{"key: (ID) -> (ID, Name)"}},
+			},
+		},
+		{
+			name:   "constraints and indexes",
+			before: schema.Schemas{users()},
+			after: func(s schema.Schema) schema.Schemas {
+				s.ForeignKeys = []schema.ForeignKey{{Name: "FK_Users_Groups", Key: []string{"Name"}, Reference: schema.ForeignKeyReference{Table: "Groups", Key: []string{"Name"}}}}
+				s.UniqueKeys = []schema.UniqueKey{{Name: "UQ_Users_Name", Key: []string{"Name"}}}
+				s.Indexes = []schema.Index{{Name: "IDX_Users_Name", Unique: true, Key: []schema.IndexKeyElem{{Name: "Name", Desc: true}}}}
+				return schema.Schemas{s}
+			},
+			want: diff.Changes{
+				{Kind: diff.KindAdded, Object: diff.ObjectForeignKey, Table: "Users", Name: "FK_Users_Groups", New: "(Name) REFERENCES Groups (Name)"},
+				{Kind: diff.KindAdded, Object: diff.ObjectUniqueKey, Table: "Users", Name: "UQ_Users_Name", New: "(Name)"},
+				{Kind: diff.KindModified, Object: diff.ObjectIndex, Table: "Users", Name: "IDX_Users_Name", Old: "(Name)", New: "UNIQUE (Name DESC)", Details: []string{"unique: false -> true", "key: (Name) -> (Name DESC)"}},
+			},
+		},
+		{
+			name: "unnamed foreign keys",
+			before: func() schema.Schemas {
+				s := users()
+				s.ForeignKeys = []schema.ForeignKey{{Key: []string{"Name"}, Reference: schema.ForeignKeyReference{Table: "Groups", Key: []string{"Name"}}}}
+				return schema.Schemas{s}
+			}(),
+			after: func(s schema.Schema) schema.Schemas {
+				s.ForeignKeys = []schema.ForeignKey{{Key: []string{"ID"}, Reference: schema.ForeignKeyReference{Table: "Groups", Key: []string{"ID"}}}}
+				return schema.Schemas{s}
+			},
+			want: diff.Changes{
+				{Kind: diff.KindRemoved, Object: diff.ObjectForeignKey, Table: "Users", Old: "(Name) REFERENCES Groups (Name)"},
+				{Kind: diff.KindAdded, Object: diff.ObjectForeignKey, Table: "Users", New: "(ID) REFERENCES Groups (ID)"},
+			},
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			after := testcase.after(users())
+
+			got, err := diff.Compare(testcase.before, after)
+
+			require.Nil(t, err)
+			require.Equal(t, testcase.want, got)
+		})
+	}
+}
+
+func TestCompare_DifferentDialects(t *testing.T) {
+	_, err := diff.Compare(schema.Schemas{users()}, schema.Schemas{{Dialect: schema.DialectPostgres, Schema: "public", Name: "Users"}})
+	require.NotNil(t, err)
+}
@@ -0,0 +1,70 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+var kindSigns = map[Kind]string{KindAdded: "+", KindRemoved: "-", KindModified: "~"}
+
+// Text renders the changes as lines such as "+ column users.email: STRING(100) NOT NULL".
+func (c Changes) Text() string {
+	var b strings.Builder
+	for _, change := range c {
+		target := change.QualifiedTable()
+		if change.Name != "" {
+			target += "." + change.Name
+		}
+		fmt.Fprintf(&b, "%s %s %s", kindSigns[change.Kind], change.Object, target)
+		switch change.Kind {
+		case KindAdded:
+			writeDefinition(&b, change.New)
+		case KindRemoved:
+			writeDefinition(&b, change.Old)
+		case KindModified:
+			writeDefinition(&b, strings.Join(change.Details, ", "))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func writeDefinition(b *strings.Builder, definition string) {
+	if definition != "" {
+		b.WriteString(": " + definition)
+	}
+}
+
+// Markdown renders the changes as a Markdown table.
+func (c Changes) Markdown() string {
+	var b strings.Builder
+	b.WriteString("| Change | Object | Table | Name | Before | After | Details |\n")
+	b.WriteString("|---|---|---|---|---|---|---|\n")
+	for _, change := range c {
+		details := make([]string, len(change.Details))
+		for i, d := range change.Details {
+			details[i] = escapeMarkdown(d)
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s | %s |\n",
+			change.Kind, change.Object, escapeMarkdown(change.QualifiedTable()), escapeMarkdown(change.Name),
+			escapeMarkdown(change.Old), escapeMarkdown(change.New), strings.Join(details, "<br>"))
+	}
+	return b.String()
+}
+
+func escapeMarkdown(s string) string {
+	return strings.NewReplacer(`|`, `\|`, `<`, `&lt;`, `>`, `&gt;`).Replace(s)
+}
+
+// JSON renders the changes as a JSON array.
+func (c Changes) JSON() ([]byte, error) {
+	if c == nil {
+		c = Changes{}
+	}
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf(`fail to marshal changes to JSON: %w`, err)
+	}
+	return b, nil
+}
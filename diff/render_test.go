@@ -0,0 +1,41 @@
+package diff_test
+
+import (
+	"github.com/Jumpaku/schenerate/diff"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+var changes = diff.Changes{
+	{Kind: diff.KindAdded, Object: diff.ObjectTable, Schema: "public", Table: "items", New: "BASE TABLE"},
+	{Kind: diff.KindRemoved, Object: diff.ObjectColumn, Schema: "public", Table: "users", Name: "note", Old: "text"},
+	{Kind: diff.KindModified, Object: diff.ObjectColumn, Schema: "public", Table: "users", Name: "name", Old: "character varying NOT NULL", New: "text", Details: []string{"type: character varying -> text", "nullable: false -> true"}},
+}
+
+func TestChanges_Text(t *testing.T) {
+	want := `+ table public.items: BASE TABLE
+- column public.users.note: text
+~ column public.users.name: type: character varying -> text, nullable: false -> true
+`
+	require.Equal(t, want, changes.Text())
+}
+
+func TestChanges_Markdown(t *testing.T) {
+	want := `| Change | Object | Table | Name | Before | After | Details |
+|---|---|---|---|---|---|---|
+| added | table | public.items |  |  | BASE TABLE |  |
+| removed | column | public.users | note | text |  |  |
+| modified | column | public.users | name | character varying NOT NULL | text | type: character varying -&gt; text<br>nullable: false -&gt; true |
+`
+	require.Equal(t, want, changes.Markdown())
+}
+
+func TestChanges_JSON(t *testing.T) {
+	got, err := changes[:1].JSON()
+	require.Nil(t, err)
+	require.JSONEq(t, `[{"kind":"added","object":"table","schema":"public","table":"items","new":"BASE TABLE"}]`, string(got))
+
+	got, err = diff.Changes(nil).JSON()
+	require.Nil(t, err)
+	require.Equal(t, `[]`, string(got))
+}
@@ -0,0 +1,19 @@
+package files
+
+import (
+	"fmt"
+	"os"
+)
+
+// ReadAll reads the files in the given order and returns their contents.
+func ReadAll(paths ...string) ([]string, error) {
+	var contents []string
+	for _, path := range paths {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf(`fail to read file %s: %w`, path, err)
+		}
+		contents = append(contents, string(b))
+	}
+	return contents, nil
+}
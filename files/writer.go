@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 )
 
 type Writer struct {
@@ -35,6 +36,17 @@ func (w *Writer) Write(b []byte) (int, error) {
 	return w.contents[len(w.contents)-1].content.Write(b)
 }
 
+// Paths returns the distinct paths of the added files in the order in which they are added.
+func (w *Writer) Paths() []string {
+	var paths []string
+	for _, content := range w.contents {
+		if !slices.Contains(paths, content.path) {
+			paths = append(paths, content.path)
+		}
+	}
+	return paths
+}
+
 func (w *Writer) SaveAll() error {
 	for _, content := range w.contents {
 		if err := saveContent(content.path, content.content); err != nil {
@@ -10,7 +10,10 @@ require (
 	github.com/mattn/go-sqlite3 v1.14.24
 	github.com/samber/lo v1.47.0
 	github.com/stretchr/testify v1.10.0
+	github.com/tetratelabs/wazero v1.8.2
+	go.starlark.net v0.0.0-20250623223156-8bf495bf4e9a
 	google.golang.org/api v0.203.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -64,5 +67,4 @@ require (
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20241015192408-796eee8c2d53 // indirect
 	google.golang.org/grpc v1.67.1 // indirect
 	google.golang.org/protobuf v1.35.1 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
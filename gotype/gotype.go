@@ -0,0 +1,80 @@
+package gotype
+
+import (
+	"slices"
+)
+
+// Type is a Go type referenced in generated code.
+type Type struct {
+	// Name is the type expression qualified by the package name, e.g. int64, *string, []byte and sql.NullString.
+	Name string
+	// Import is the import path of the package which the type requires, which is empty for predeclared types.
+	Import string
+}
+
+func (t Type) String() string {
+	return t.Name
+}
+
+// Pointer returns the pointer type to t.
+func (t Type) Pointer() Type {
+	return Type{Name: "*" + t.Name, Import: t.Import}
+}
+
+// Slice returns the slice type of t.
+func (t Type) Slice() Type {
+	return Type{Name: "[]" + t.Name, Import: t.Import}
+}
+
+// Imports returns the sorted distinct import paths required by the types.
+func Imports(types ...Type) []string {
+	var imports []string
+	for _, t := range types {
+		if t.Import != "" && !slices.Contains(imports, t.Import) {
+			imports = append(imports, t.Import)
+		}
+	}
+	slices.Sort(imports)
+	return imports
+}
+
+// NullStyle is how nullable columns are represented in Go.
+type NullStyle string
+
+const (
+	// NullStylePointer represents nullable columns as pointers, e.g. *string.
+	NullStylePointer NullStyle = "pointer"
+	// NullStyleDatabaseSQL represents nullable columns as the types in database/sql, e.g. sql.NullString.
+	NullStyleDatabaseSQL NullStyle = "database/sql"
+	// NullStyleDriver represents nullable columns as the types provided by the database driver, e.g. spanner.NullString and pgtype.Text.
+	NullStyleDriver NullStyle = "driver"
+)
+
+// Mapping is a pair of Go types for NOT NULL and nullable columns.
+type Mapping struct {
+	Type Type
+	// Null is the type of nullable columns. If it is empty, the pointer to Type is used.
+	Null Type
+}
+
+// Nullable returns the mapping whose nullable type is selected by the style.
+// If the type for the style is empty, it falls back to databaseSQL for NullStyleDriver and then the pointer to t.
+func Nullable(style NullStyle, t, databaseSQL, driver Type) Mapping {
+	switch style {
+	case NullStyleDriver:
+		if driver.Name != "" {
+			return Mapping{Type: t, Null: driver}
+		}
+		fallthrough
+	case NullStyleDatabaseSQL:
+		if databaseSQL.Name != "" {
+			return Mapping{Type: t, Null: databaseSQL}
+		}
+	}
+	return Mapping{Type: t}
+}
+
+// Same returns the mapping to t regardless of nullability, which is suitable for types representing null by themselves, e.g. []byte.
+func Same(t Type) Mapping {
+	return Mapping{Type: t, Null: t}
+}
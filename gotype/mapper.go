@@ -0,0 +1,82 @@
+package gotype
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Defaults returns the default mapping of the column type in the style, or false if the column type is not supported.
+type Defaults func(columnType string, style NullStyle) (Mapping, bool)
+
+// Mapper maps columns to Go types by the defaults of a dialect, which can be overridden by type patterns and specific columns.
+type Mapper struct {
+	defaults        Defaults
+	style           NullStyle
+	typeOverrides   []typeOverride
+	columnOverrides map[columnKey]Mapping
+}
+
+type typeOverride struct {
+	pattern string
+	mapping Mapping
+}
+
+type columnKey struct {
+	table  string
+	column string
+}
+
+func NewMapper(defaults Defaults, style NullStyle) *Mapper {
+	return &Mapper{defaults: defaults, style: style, columnOverrides: map[columnKey]Mapping{}}
+}
+
+// OverrideType overrides the mapping of column types matching the pattern, which is a case-insensitive glob of path.Match, e.g. "STRING(*)" and "character varying*".
+// Patterns are tried in the order in which they are added.
+func (m *Mapper) OverrideType(pattern string, mapping Mapping) *Mapper {
+	m.typeOverrides = append(m.typeOverrides, typeOverride{pattern: strings.ToLower(pattern), mapping: mapping})
+	return m
+}
+
+// OverrideColumn overrides the mapping of the column of the table, which takes precedence over OverrideType.
+func (m *Mapper) OverrideColumn(table, column string, mapping Mapping) *Mapper {
+	m.columnOverrides[columnKey{table: table, column: column}] = mapping
+	return m
+}
+
+// Map returns the Go type of the column of the table.
+func (m *Mapper) Map(table, column, columnType string, nullable bool) (Type, error) {
+	mapping, err := m.Mapping(table, column, columnType)
+	if err != nil {
+		return Type{}, err
+	}
+	if !nullable {
+		return mapping.Type, nil
+	}
+	if mapping.Null.Name == "" {
+		return mapping.Type.Pointer(), nil
+	}
+	return mapping.Null, nil
+}
+
+// Mapping returns the mapping of the column of the table.
+func (m *Mapper) Mapping(table, column, columnType string) (Mapping, error) {
+	if mapping, ok := m.columnOverrides[columnKey{table: table, column: column}]; ok {
+		return mapping, nil
+	}
+	for _, o := range m.typeOverrides {
+		matched, err := path.Match(o.pattern, strings.ToLower(columnType))
+		if err != nil {
+			return Mapping{}, fmt.Errorf(`fail to match type pattern %q: %w`, o.pattern, err)
+		}
+		if matched {
+			return o.mapping, nil
+		}
+	}
+	if m.defaults != nil {
+		if mapping, ok := m.defaults(columnType, m.style); ok {
+			return mapping, nil
+		}
+	}
+	return Mapping{}, fmt.Errorf(`unsupported column type %q of %s.%s`, columnType, table, column)
+}
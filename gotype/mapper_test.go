@@ -0,0 +1,99 @@
+package gotype_test
+
+import (
+	"github.com/Jumpaku/schenerate/gotype"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func defaults(columnType string, style gotype.NullStyle) (gotype.Mapping, bool) {
+	switch columnType {
+	case "STRING":
+		return gotype.Nullable(style, gotype.Type{Name: "string"},
+			gotype.Type{Name: "sql.NullString", Import: "database/sql"},
+			gotype.Type{Name: "spanner.NullString", Import: "cloud.google.com/go/spanner"}), true
+	case "FLOAT32":
+		return gotype.Nullable(style, gotype.Type{Name: "float32"}, gotype.Type{}, gotype.Type{}), true
+	case "BYTES":
+		return gotype.Same(gotype.Type{Name: "[]byte"}), true
+	default:
+		return gotype.Mapping{}, false
+	}
+}
+
+func TestMapper_Map(t *testing.T) {
+	uuid := gotype.Type{Name: "uuid.UUID", Import: "github.com/google/uuid"}
+	email := gotype.Type{Name: "mail.Address", Import: "net/mail"}
+	testcases := []struct {
+		name         string
+		style        gotype.NullStyle
+		inTable      string
+		inColumn     string
+		inColumnType string
+		inNullable   bool
+		want         gotype.Type
+		wantErr      bool
+	}{
+		{
+			name: "not null", style: gotype.NullStylePointer,
+			inColumnType: "STRING", want: gotype.Type{Name: "string"},
+		},
+		{
+			name: "pointer", style: gotype.NullStylePointer,
+			inColumnType: "STRING", inNullable: true, want: gotype.Type{Name: "*string"},
+		},
+		{
+			name: "database/sql", style: gotype.NullStyleDatabaseSQL,
+			inColumnType: "STRING", inNullable: true, want: gotype.Type{Name: "sql.NullString", Import: "database/sql"},
+		},
+		{
+			name: "driver", style: gotype.NullStyleDriver,
+			inColumnType: "STRING", inNullable: true, want: gotype.Type{Name: "spanner.NullString", Import: "cloud.google.com/go/spanner"},
+		},
+		{
+			name: "driver falls back to pointer", style: gotype.NullStyleDriver,
+			inColumnType: "FLOAT32", inNullable: true, want: gotype.Type{Name: "*float32"},
+		},
+		{
+			name: "same", style: gotype.NullStyleDatabaseSQL,
+			inColumnType: "BYTES", inNullable: true, want: gotype.Type{Name: "[]byte"},
+		},
+		{
+			name: "type override", style: gotype.NullStylePointer,
+			inColumnType: "string(36)", inNullable: true, want: uuid.Pointer(),
+		},
+		{
+			name: "column override", style: gotype.NullStylePointer,
+			inTable: "User", inColumn: "Email", inColumnType: "STRING(36)", want: email,
+		},
+		{
+			name: "unsupported", style: gotype.NullStylePointer,
+			inColumnType: "INTERVAL", wantErr: true,
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			m := gotype.NewMapper(defaults, testcase.style).
+				OverrideType("STRING(36)", gotype.Mapping{Type: uuid}).
+				OverrideColumn("User", "Email", gotype.Same(email))
+
+			got, err := m.Map(testcase.inTable, testcase.inColumn, testcase.inColumnType, testcase.inNullable)
+			if testcase.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, testcase.want, got)
+		})
+	}
+}
+
+func TestImports(t *testing.T) {
+	got := gotype.Imports(
+		gotype.Type{Name: "time.Time", Import: "time"},
+		gotype.Type{Name: "int64"},
+		gotype.Type{Name: "sql.NullString", Import: "database/sql"},
+		gotype.Type{Name: "sql.NullTime", Import: "database/sql"},
+	)
+	require.Equal(t, []string{"database/sql", "time"}, got)
+}
@@ -0,0 +1,14 @@
+// Package slice provides helpers for slices shared by the backends.
+package slice
+
+// Map is lo.Map that keeps nil as nil so that conversions between the backend schemas and the unified schemas are lossless.
+func Map[T, R any](s []T, f func(T) R) []R {
+	if s == nil {
+		return nil
+	}
+	r := make([]R, len(s))
+	for i, v := range s {
+		r[i] = f(v)
+	}
+	return r
+}
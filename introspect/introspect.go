@@ -0,0 +1,147 @@
+package introspect
+
+import (
+	"context"
+	"fmt"
+	"github.com/Jumpaku/schenerate/schema"
+	"slices"
+	"sync"
+)
+
+// Introspector lists tables and their schemas of a database.
+// Packages supporting databases other than the built-in backends implement it and register it with Register.
+type Introspector interface {
+	// ListTables lists the tables in the database.
+	ListTables(ctx context.Context) ([]schema.Table, error)
+	// ListSchemas lists the schemas of the tables.
+	ListSchemas(ctx context.Context, tables []string) (schema.Schemas, error)
+	// Close closes the connection to the database.
+	Close() error
+}
+
+// tableSelector is implemented by an Introspector which selects the names of the tables by itself,
+// e.g. qualified by the schemas, instead of the bare names of the tables selected from ListTables.
+type tableSelector interface {
+	SelectTables(ctx context.Context, selector schema.Selector) ([]string, error)
+}
+
+// referenceLister is implemented by an Introspector which lists the references of all the tables as schema.Schemas.References
+// without listing the schemas of all the tables.
+type referenceLister interface {
+	ListReferences(ctx context.Context) (map[string][]string, error)
+}
+
+// OpenFunc opens an Introspector connected to the database specified by dataSource, whose format depends on the driver.
+type OpenFunc func(ctx context.Context, dataSource string) (Introspector, error)
+
+var (
+	driversMu sync.RWMutex
+	drivers   = map[string]OpenFunc{}
+)
+
+// Register makes an Introspector available by the driver name.
+// It panics if it is called twice with the same name or if open is nil.
+func Register(driver string, open OpenFunc) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+
+	if open == nil {
+		panic(fmt.Sprintf(`introspect: Register open func is nil for driver %q`, driver))
+	}
+	if _, dup := drivers[driver]; dup {
+		panic(fmt.Sprintf(`introspect: Register called twice for driver %q`, driver))
+	}
+	drivers[driver] = open
+}
+
+// Open opens an Introspector with the registered driver.
+func Open(ctx context.Context, driver, dataSource string) (Introspector, error) {
+	driversMu.RLock()
+	open, ok := drivers[driver]
+	driversMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf(`unknown driver %q (forgotten import?)`, driver)
+	}
+	i, err := open(ctx, dataSource)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to open driver %q: %w`, driver, err)
+	}
+	return i, nil
+}
+
+// ListSchemas lists the schemas of the tables, or of all the tables except the system tables if tables is empty,
+// which are expanded by the references and the referrers according to opts.
+func ListSchemas(ctx context.Context, in Introspector, tables []string, opts ...schema.ListOption) (schema.Schemas, error) {
+	if len(tables) == 0 {
+		return SelectSchemas(ctx, in, schema.All())
+	}
+	schemas, err := schema.Expand(ctx, tables, opts,
+		func(ctx context.Context, tables []string) ([]schema.Schema, error) {
+			return in.ListSchemas(ctx, tables)
+		},
+		func(s schema.Schema) schema.Schema { return s },
+		func(ctx context.Context) (map[string][]string, error) {
+			if r, ok := in.(referenceLister); ok {
+				return r.ListReferences(ctx)
+			}
+			tables, err := SelectSchemas(ctx, in, schema.All())
+			if err != nil {
+				return nil, err
+			}
+			return tables.References(), nil
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to list schemas: %w`, err)
+	}
+	return schemas, nil
+}
+
+// SelectTables returns the names of the tables selected by the selector, which can be passed to ListSchemas.
+func SelectTables(ctx context.Context, in Introspector, selector schema.Selector) ([]string, error) {
+	if s, ok := in.(tableSelector); ok {
+		names, err := s.SelectTables(ctx, selector)
+		if err != nil {
+			return nil, fmt.Errorf(`fail to select tables: %w`, err)
+		}
+		return names, nil
+	}
+	tables, err := in.ListTables(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to list tables: %w`, err)
+	}
+	names, err := selector.SelectNames(tables)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to select tables: %w`, err)
+	}
+	return names, nil
+}
+
+// SelectSchemas lists the schemas of the tables selected by the selector.
+func SelectSchemas(ctx context.Context, in Introspector, selector schema.Selector) (schema.Schemas, error) {
+	tables, err := SelectTables(ctx, in, selector)
+	if err != nil {
+		return nil, err
+	}
+	if len(tables) == 0 {
+		return schema.Schemas{}, nil
+	}
+	schemas, err := in.ListSchemas(ctx, tables)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to list schemas: %w`, err)
+	}
+	return schemas, nil
+}
+
+// Drivers returns the sorted names of the registered drivers.
+func Drivers() []string {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
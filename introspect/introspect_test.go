@@ -0,0 +1,81 @@
+package introspect_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/Jumpaku/schenerate/introspect"
+	_ "github.com/Jumpaku/schenerate/postgres"
+	"github.com/Jumpaku/schenerate/schema"
+	_ "github.com/Jumpaku/schenerate/spanner"
+	_ "github.com/Jumpaku/schenerate/sqlite3"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+)
+
+func TestDrivers(t *testing.T) {
+	require.Subset(t, introspect.Drivers(), []string{"postgres", "spanner", "sqlite3"})
+}
+
+func TestOpen(t *testing.T) {
+	t.Run("sqlite3", func(t *testing.T) {
+		i, err := introspect.Open(context.Background(), "sqlite3", filepath.Join(t.TempDir(), "test.sqlite"))
+		require.Nil(t, err)
+		defer i.Close()
+
+		_, err = i.ListTables(context.Background())
+		require.Nil(t, err)
+	})
+	t.Run("unknown driver", func(t *testing.T) {
+		_, err := introspect.Open(context.Background(), "unknown", "")
+		require.NotNil(t, err)
+	})
+	t.Run("invalid spanner data source", func(t *testing.T) {
+		_, err := introspect.Open(context.Background(), "spanner", "projects/p/instances/i")
+		require.NotNil(t, err)
+	})
+}
+
+func TestRegister(t *testing.T) {
+	open := func(ctx context.Context, dataSource string) (introspect.Introspector, error) { return nil, nil }
+	introspect.Register("test", open)
+	require.Contains(t, introspect.Drivers(), "test")
+	require.Panics(t, func() { introspect.Register("test", open) })
+	require.Panics(t, func() { introspect.Register("nil", nil) })
+}
+
+// fake is an Introspector of the schemas without ListReferences.
+type fake schema.Schemas
+
+func (f fake) ListTables(ctx context.Context) ([]schema.Table, error) {
+	return lo.Map(f, func(s schema.Schema, _ int) schema.Table {
+		return schema.Table{Name: s.Name, Type: schema.TableTypeTable}
+	}), nil
+}
+
+func (f fake) ListSchemas(ctx context.Context, tables []string) (schema.Schemas, error) {
+	var schemas schema.Schemas
+	for _, t := range tables {
+		s, ok := schema.Schemas(f).Find("", t)
+		if !ok {
+			return nil, fmt.Errorf(`table %s not found`, t)
+		}
+		schemas = append(schemas, s)
+	}
+	return schemas, nil
+}
+
+func (f fake) Close() error { return nil }
+
+func TestListSchemas(t *testing.T) {
+	in := fake{
+		{Name: "users"},
+		{Name: "items", ForeignKeys: []schema.ForeignKey{{Reference: schema.ForeignKeyReference{Table: "users"}}}},
+		{Name: "tags"},
+	}
+
+	got, err := introspect.ListSchemas(context.Background(), in, []string{"users"}, schema.WithReferrers(-1))
+	require.Nil(t, err)
+	require.Equal(t, []string{"users", "items"}, lo.Map(got, func(s schema.Schema, _ int) string { return s.Name }))
+}
@@ -0,0 +1,251 @@
+// Package introspecttest provides a conformance test suite for implementations of introspect.Introspector.
+package introspecttest
+
+import (
+	"context"
+	"fmt"
+	"github.com/Jumpaku/schenerate/introspect"
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"io/fs"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Scenario is a DDL scenario of the conformance test suite.
+type Scenario string
+
+const (
+	// ScenarioAllTypes creates table A with column PK as the primary key and columns Col_NN of various types, which are nullable if and only if NN is odd.
+	ScenarioAllTypes Scenario = "all_types"
+	// ScenarioForeignKeys creates tables C_1, ..., C_5 referencing each other by foreign keys without loops.
+	ScenarioForeignKeys Scenario = "foreign_keys"
+	// ScenarioForeignLoop1 creates table D_1 referencing itself.
+	ScenarioForeignLoop1 Scenario = "foreign_loop_1"
+	// ScenarioForeignLoop2 creates tables E_1 and E_2 referencing each other.
+	ScenarioForeignLoop2 Scenario = "foreign_loop_2"
+	// ScenarioForeignLoop3 creates tables F_1, F_2 and F_3 referencing each other in a loop.
+	ScenarioForeignLoop3 Scenario = "foreign_loop_3"
+	// ScenarioUniqueKeysIndex creates table G with unique indexes.
+	ScenarioUniqueKeysIndex Scenario = "unique_keys_index"
+	// ScenarioUniqueKeysConstraint creates table H with unique constraints.
+	ScenarioUniqueKeysConstraint Scenario = "unique_keys_constraint"
+	// ScenarioUniqueKeysColumn creates table I with unique columns.
+	ScenarioUniqueKeysColumn Scenario = "unique_keys_column"
+)
+
+// Scenarios is all the scenarios of the conformance test suite.
+var Scenarios = []Scenario{
+	ScenarioAllTypes,
+	ScenarioForeignKeys,
+	ScenarioForeignLoop1,
+	ScenarioForeignLoop2,
+	ScenarioForeignLoop3,
+	ScenarioUniqueKeysIndex,
+	ScenarioUniqueKeysConstraint,
+	ScenarioUniqueKeysColumn,
+}
+
+// ddlPrefixes is the prefixes of the names of the DDL files of the scenarios, e.g. "ddl_02_foreign_keys.sql".
+var ddlPrefixes = map[Scenario]string{
+	ScenarioAllTypes:             "ddl_00_",
+	ScenarioForeignKeys:          "ddl_02_",
+	ScenarioForeignLoop1:         "ddl_03_",
+	ScenarioForeignLoop2:         "ddl_04_",
+	ScenarioForeignLoop3:         "ddl_05_",
+	ScenarioUniqueKeysIndex:      "ddl_06_",
+	ScenarioUniqueKeysConstraint: "ddl_07_",
+	ScenarioUniqueKeysColumn:     "ddl_08_",
+}
+
+// readDDL reads the DDL file of the scenario, which is named with the prefix of the scenario and the extension .sql.
+func readDDL(fsys fs.FS, scenario Scenario) (string, bool, error) {
+	matches, err := fs.Glob(fsys, ddlPrefixes[scenario]+"*.sql")
+	if err != nil {
+		return "", false, fmt.Errorf(`fail to find DDL file: %w`, err)
+	}
+	switch len(matches) {
+	case 0:
+		return "", false, nil
+	case 1:
+		b, err := fs.ReadFile(fsys, matches[0])
+		if err != nil {
+			return "", false, fmt.Errorf(`fail to read DDL file: %w`, err)
+		}
+		return string(b), true, nil
+	default:
+		return "", false, fmt.Errorf(`multiple DDL files found: %v`, matches)
+	}
+}
+
+// Config configures the conformance test suite.
+type Config struct {
+	// Setup creates a database by executing ddl, which may consist of multiple statements, and returns an Introspector connected to it.
+	// Resources should be released by t.Cleanup.
+	Setup func(t *testing.T, ddl string) introspect.Introspector
+	// DDL is the filesystem of the DDL files of the scenarios in the dialect of the database, e.g. os.DirFS("testdata/list"),
+	// where the DDL files are named ddl_00_*.sql for ScenarioAllTypes, ddl_02_*.sql for ScenarioForeignKeys, ..., and ddl_08_*.sql for ScenarioUniqueKeysColumn
+	// in the order of Scenarios as the testdata of the built-in backends. Scenarios without DDL files are skipped.
+	DDL fs.FS
+}
+
+// Run runs the conformance test suite as subtests of t.
+func Run(t *testing.T, c Config) {
+	t.Helper()
+	for _, scenario := range Scenarios {
+		t.Run(string(scenario), func(t *testing.T) {
+			ddl, ok, err := readDDL(c.DDL, scenario)
+			if err != nil {
+				t.Fatalf(`fail to read DDL for scenario %q: %v`, scenario, err)
+			}
+			if !ok {
+				t.Skipf(`DDL for scenario %q is not given`, scenario)
+			}
+			i := c.Setup(t, ddl)
+			runScenario(t, i, expectations[scenario])
+		})
+	}
+}
+
+type foreignKey struct {
+	Key       []string
+	Reference string
+	RefKey    []string
+}
+
+type table struct {
+	name        string
+	primaryKey  []string
+	foreignKeys []foreignKey
+	// uniqueKeys is checked if not nil.
+	uniqueKeys [][]string
+}
+
+func pk(ks ...string) []string { return ks }
+
+var expectations = map[Scenario][]table{
+	ScenarioAllTypes: {
+		{name: "A", primaryKey: pk("PK")},
+	},
+	ScenarioForeignKeys: {
+		{name: "C_1", primaryKey: pk("PK_11", "PK_12")},
+		{name: "C_2", primaryKey: pk("PK_21", "PK_22"), foreignKeys: []foreignKey{
+			{Key: pk("PK_21", "PK_22"), Reference: "C_1", RefKey: pk("PK_11", "PK_12")},
+		}},
+		{name: "C_3", primaryKey: pk("PK_31", "PK_32"), foreignKeys: []foreignKey{
+			{Key: pk("PK_31", "PK_32"), Reference: "C_2", RefKey: pk("PK_21", "PK_22")},
+		}},
+		{name: "C_4", primaryKey: pk("PK_41", "PK_42"), foreignKeys: []foreignKey{
+			{Key: pk("PK_41", "PK_42"), Reference: "C_2", RefKey: pk("PK_21", "PK_22")},
+		}},
+		{name: "C_5", primaryKey: pk("PK_51", "PK_52"), foreignKeys: []foreignKey{
+			{Key: pk("PK_51", "PK_52"), Reference: "C_3", RefKey: pk("PK_31", "PK_32")},
+			{Key: pk("PK_51", "PK_52"), Reference: "C_4", RefKey: pk("PK_41", "PK_42")},
+		}},
+	},
+	ScenarioForeignLoop1: {
+		{name: "D_1", primaryKey: pk("PK_11", "PK_12"), foreignKeys: []foreignKey{
+			{Key: pk("PK_11"), Reference: "D_1", RefKey: pk("PK_12")},
+		}},
+	},
+	ScenarioForeignLoop2: {
+		{name: "E_1", primaryKey: pk("PK_11", "PK_12"), foreignKeys: []foreignKey{
+			{Key: pk("PK_11", "PK_12"), Reference: "E_2", RefKey: pk("PK_21", "PK_22")},
+		}},
+		{name: "E_2", primaryKey: pk("PK_21", "PK_22"), foreignKeys: []foreignKey{
+			{Key: pk("PK_21", "PK_22"), Reference: "E_1", RefKey: pk("PK_11", "PK_12")},
+		}},
+	},
+	ScenarioForeignLoop3: {
+		{name: "F_1", primaryKey: pk("PK_11", "PK_12"), foreignKeys: []foreignKey{
+			{Key: pk("PK_11", "PK_12"), Reference: "F_3", RefKey: pk("PK_31", "PK_32")},
+		}},
+		{name: "F_2", primaryKey: pk("PK_21", "PK_22"), foreignKeys: []foreignKey{
+			{Key: pk("PK_21", "PK_22"), Reference: "F_1", RefKey: pk("PK_11", "PK_12")},
+		}},
+		{name: "F_3", primaryKey: pk("PK_31", "PK_32"), foreignKeys: []foreignKey{
+			{Key: pk("PK_31", "PK_32"), Reference: "F_2", RefKey: pk("PK_21", "PK_22")},
+		}},
+	},
+	ScenarioUniqueKeysIndex:      {{name: "G", primaryKey: pk("PK"), uniqueKeys: permutations}},
+	ScenarioUniqueKeysConstraint: {{name: "H", primaryKey: pk("PK"), uniqueKeys: permutations}},
+	ScenarioUniqueKeysColumn: {
+		{name: "I", primaryKey: pk("PK"), uniqueKeys: [][]string{{"C1"}, {"C2"}, {"C3"}}},
+	},
+}
+
+var permutations = [][]string{
+	{"C1"}, {"C2"}, {"C3"},
+	{"C1", "C2"}, {"C2", "C1"}, {"C2", "C3"}, {"C3", "C2"}, {"C3", "C1"}, {"C1", "C3"},
+	{"C1", "C2", "C3"}, {"C1", "C3", "C2"}, {"C2", "C3", "C1"}, {"C2", "C1", "C3"}, {"C3", "C1", "C2"}, {"C3", "C2", "C1"},
+}
+
+var colNN = regexp.MustCompile(`^Col_(\d+)$`)
+
+func runScenario(t *testing.T, i introspect.Introspector, want []table) {
+	t.Helper()
+	ctx := context.Background()
+
+	tables, err := i.ListTables(ctx)
+	require.Nil(t, err)
+	tableNames := lo.Map(tables, func(t schema.Table, _ int) string { return t.Name })
+	for _, w := range want {
+		require.Contains(t, tableNames, w.name)
+	}
+
+	schemas, err := i.ListSchemas(ctx, lo.Map(want, func(w table, _ int) string { return w.name }))
+	require.Nil(t, err)
+	require.Len(t, schemas, len(want))
+
+	for _, w := range want {
+		got, ok := lo.Find(schemas, func(s schema.Schema) bool { return s.Name == w.name })
+		require.Truef(t, ok, `schema of %q not found`, w.name)
+
+		require.Equalf(t, w.primaryKey, got.PrimaryKey, `primary key of %q`, w.name)
+
+		for _, c := range got.Columns {
+			wantNullable := false
+			if m := colNN.FindStringSubmatch(c.Name); m != nil {
+				n, _ := strconv.Atoi(m[1])
+				wantNullable = n%2 == 1
+			}
+			require.Equalf(t, wantNullable, c.Nullable, `nullable of %q.%q`, w.name, c.Name)
+		}
+		for _, k := range w.primaryKey {
+			_, ok := got.Column(k)
+			require.Truef(t, ok, `column %q of %q not found`, k, w.name)
+		}
+
+		gotForeignKeys := lo.Map(got.ForeignKeys, func(fk schema.ForeignKey, _ int) foreignKey {
+			return foreignKey{Key: fk.Key, Reference: fk.Reference.Table, RefKey: fk.Reference.Key}
+		})
+		if w.foreignKeys == nil {
+			require.Emptyf(t, gotForeignKeys, `foreign keys of %q`, w.name)
+		} else {
+			require.ElementsMatchf(t, w.foreignKeys, gotForeignKeys, `foreign keys of %q`, w.name)
+		}
+
+		if w.uniqueKeys != nil {
+			require.ElementsMatchf(t, w.uniqueKeys, uniqueKeys(got), `unique keys of %q`, w.name)
+		}
+	}
+}
+
+// uniqueKeys returns the distinct column lists of the unique constraints and the unique indexes other than the primary key.
+func uniqueKeys(s schema.Schema) [][]string {
+	var keys [][]string
+	for _, u := range s.UniqueKeys {
+		keys = append(keys, u.Key)
+	}
+	for _, idx := range s.Indexes {
+		if idx.Unique {
+			keys = append(keys, lo.Map(idx.Key, func(e schema.IndexKeyElem, _ int) string { return e.Name }))
+		}
+	}
+	joined := func(k []string) string { return strings.Join(k, "\x00") }
+	keys = lo.UniqBy(keys, joined)
+	return lo.Reject(keys, func(k []string, _ int) bool { return joined(k) == joined(s.PrimaryKey) })
+}
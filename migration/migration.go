@@ -0,0 +1,392 @@
+package migration
+
+import (
+	"fmt"
+	"github.com/Jumpaku/schenerate/ddl"
+	"github.com/Jumpaku/schenerate/diff"
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/samber/lo"
+	"slices"
+	"strings"
+)
+
+// Statement is a statement of a migration.
+type Statement struct {
+	SQL string `json:"sql"`
+	// Destructive is true if the statement may lose data, e.g. dropping tables or columns and changing types of columns.
+	Destructive bool `json:"destructive"`
+}
+
+// Migration is a sequence of statements migrating a database from a schema to another.
+type Migration struct {
+	Dialect    schema.Dialect `json:"dialect"`
+	Statements []Statement    `json:"statements"`
+}
+
+// Destructive returns true if any of the statements is destructive.
+func (m Migration) Destructive() bool {
+	return lo.ContainsBy(m.Statements, func(s Statement) bool { return s.Destructive })
+}
+
+// SQL returns the statements terminated by semicolons, where destructive statements are preceded by comments.
+func (m Migration) SQL() string {
+	var b strings.Builder
+	for _, s := range m.Statements {
+		if s.Destructive {
+			b.WriteString("-- destructive\n")
+		}
+		b.WriteString(s.SQL + ";\n")
+	}
+	return b.String()
+}
+
+// Generate generates the migration from before to after, which must be of the same dialect.
+// Statements are ordered as follows:
+// dropping foreign keys, dropping indexes and unique keys, dropping tables, creating tables, altering tables,
+// and then creating unique keys, indexes and foreign keys.
+// Tables are dropped and created in the order of the dependencies by foreign keys and interleaving.
+// Tables of SQLite3 are rebuilt if they are changed in ways that ALTER TABLE does not support,
+// and tables of Spanner are recreated if their primary keys or parents are changed,
+// where the foreign keys referencing them are dropped and added around the recreation.
+func Generate(before, after schema.Schemas) (Migration, error) {
+	changes, err := diff.Compare(before, after)
+	if err != nil {
+		return Migration{}, fmt.Errorf(`fail to compare schemas: %w`, err)
+	}
+
+	d := dialectOf(before, after)
+	g := &generator{dialect: d}
+	type key struct {
+		schema string
+		table  string
+	}
+	var keys []key
+	tableChanges := map[key]diff.Changes{}
+	for _, c := range changes {
+		k := key{schema: c.Schema, table: c.Table}
+		if _, ok := tableChanges[k]; !ok {
+			keys = append(keys, k)
+		}
+		tableChanges[k] = append(tableChanges[k], c)
+	}
+
+	dropped, created := map[key]bool{}, map[key]bool{}
+	for _, k := range keys {
+		b, _ := before.Find(k.schema, k.table)
+		a, _ := after.Find(k.schema, k.table)
+		cs := tableChanges[k]
+		switch {
+		case cs[0].Object == diff.ObjectTable && cs[0].Kind == diff.KindRemoved:
+			dropped[k] = true
+		case cs[0].Object == diff.ObjectTable && cs[0].Kind == diff.KindAdded:
+			created[k] = true
+		case d == schema.DialectSpanner && mustRecreate(cs):
+			dropped[k], created[k] = true, true
+		case d == schema.DialectSQLite3 && mustRebuild(a, cs):
+			if err := g.rebuild(b, a); err != nil {
+				return Migration{}, err
+			}
+		default:
+			if err := g.alter(b, a, cs); err != nil {
+				return Migration{}, err
+			}
+		}
+	}
+
+	// Foreign keys of the other tables referencing the recreated tables are dropped before and added after recreating them.
+	for _, b := range before {
+		k := key{schema: b.Schema, table: b.Name}
+		if dropped[k] {
+			continue
+		}
+		a, _ := after.Find(b.Schema, b.Name)
+		for _, fk := range b.ForeignKeys {
+			ref := key{schema: fk.Reference.Schema, table: fk.Reference.Table}
+			if !dropped[ref] || !created[ref] {
+				continue
+			}
+			if lo.ContainsBy(tableChanges[k], func(c diff.Change) bool { return c.Object == diff.ObjectForeignKey && c.Name == fk.Name }) {
+				// The foreign key is already dropped and added by alter.
+				continue
+			}
+			if err := g.dropForeignKey(b, fk); err != nil {
+				return Migration{}, err
+			}
+			if err := g.addForeignKey(a, fk); err != nil {
+				return Migration{}, err
+			}
+		}
+	}
+
+	var dropTables, createTables []Statement
+	order, _ := ddl.CreationOrder(before)
+	slices.Reverse(order)
+	for _, i := range order {
+		if t := before[i]; dropped[key{schema: t.Schema, table: t.Name}] {
+			if err := g.drop(t); err != nil {
+				return Migration{}, err
+			}
+			dropTables = append(dropTables, Statement{SQL: ddl.DropTable(d, t), Destructive: true})
+		}
+	}
+	order, _ = ddl.CreationOrder(after)
+	for _, i := range order {
+		if t := after[i]; created[key{schema: t.Schema, table: t.Name}] {
+			stmt, err := g.create(t)
+			if err != nil {
+				return Migration{}, err
+			}
+			createTables = append(createTables, stmt)
+		}
+	}
+
+	m := Migration{Dialect: d}
+	if g.rebuilt {
+		m.Statements = append(m.Statements, Statement{SQL: "PRAGMA foreign_keys = OFF"})
+	}
+	m.Statements = lo.Flatten([][]Statement{m.Statements, g.dropForeignKeys, g.dropIndexes, dropTables, createTables, g.alters, g.creates})
+	if g.rebuilt {
+		m.Statements = append(m.Statements, Statement{SQL: "PRAGMA foreign_key_check"}, Statement{SQL: "PRAGMA foreign_keys = ON"})
+	}
+	return m, nil
+}
+
+func dialectOf(before, after schema.Schemas) schema.Dialect {
+	for _, s := range append(slices.Clone(before), after...) {
+		if s.Dialect != "" {
+			return s.Dialect
+		}
+	}
+	return ""
+}
+
+// mustRecreate returns true if the primary key or the parent of the Spanner table is changed, which cannot be altered.
+func mustRecreate(changes diff.Changes) bool {
+	return lo.ContainsBy(changes, func(c diff.Change) bool {
+		return c.Object == diff.ObjectPrimaryKey ||
+			(c.Object == diff.ObjectTable && lo.ContainsBy(c.Details, func(d string) bool { return strings.HasPrefix(d, "parent:") }))
+	})
+}
+
+// mustRebuild returns true if the SQLite3 table is changed other than creating and dropping indexes and adding nullable columns.
+func mustRebuild(after schema.Schema, changes diff.Changes) bool {
+	return lo.ContainsBy(changes, func(c diff.Change) bool {
+		switch c.Object {
+		case diff.ObjectTable, diff.ObjectIndex:
+			return false
+		case diff.ObjectColumn:
+			col, _ := after.Column(c.Name)
+			return c.Kind != diff.KindAdded || !col.Nullable
+		default:
+			return true
+		}
+	})
+}
+
+type generator struct {
+	dialect         schema.Dialect
+	dropForeignKeys []Statement
+	dropIndexes     []Statement
+	alters          []Statement
+	creates         []Statement
+	rebuilt         bool
+}
+
+// drop drops the foreign keys and the indexes of the table to be dropped.
+func (g *generator) drop(t schema.Schema) error {
+	if g.dialect == schema.DialectSQLite3 {
+		return nil
+	}
+	for _, fk := range t.ForeignKeys {
+		if err := g.dropForeignKey(t, fk); err != nil {
+			return err
+		}
+	}
+	if g.dialect == schema.DialectSpanner {
+		for _, uk := range t.UniqueKeys {
+			if err := g.dropUniqueKey(t, uk); err != nil {
+				return err
+			}
+		}
+		for _, idx := range ddl.Indexes(g.dialect, t) {
+			g.dropIndexes = append(g.dropIndexes, Statement{SQL: ddl.DropIndex(g.dialect, t, idx)})
+		}
+	}
+	return nil
+}
+
+// create returns the statement creating the table and creates its unique keys, indexes and foreign keys later.
+func (g *generator) create(t schema.Schema) (Statement, error) {
+	table := t
+	if g.dialect != schema.DialectSQLite3 {
+		table.ForeignKeys = nil
+		for _, fk := range t.ForeignKeys {
+			if err := g.addForeignKey(t, fk); err != nil {
+				return Statement{}, err
+			}
+		}
+	}
+	if g.dialect == schema.DialectSpanner {
+		for _, uk := range t.UniqueKeys {
+			if err := g.addUniqueKey(t, uk); err != nil {
+				return Statement{}, err
+			}
+		}
+	}
+	for _, idx := range ddl.Indexes(g.dialect, t) {
+		g.creates = append(g.creates, Statement{SQL: ddl.CreateIndex(g.dialect, t, idx)})
+	}
+	return Statement{SQL: ddl.CreateTable(g.dialect, table)}, nil
+}
+
+// rebuild rebuilds the SQLite3 table by creating a new table, copying rows, dropping the old table and renaming the new table.
+// https://www.sqlite.org/lang_altertable.html#otheralter
+func (g *generator) rebuild(before, after schema.Schema) error {
+	d := g.dialect
+	g.rebuilt = true
+
+	tmp := after
+	tmp.Name = after.Name + "_new"
+	rename, err := ddl.RenameTable(d, tmp, after.Name)
+	if err != nil {
+		return err
+	}
+
+	var columns []string
+	destructive := false
+	for _, b := range before.Columns {
+		a, ok := after.Column(b.Name)
+		if !ok || a.Type != b.Type {
+			destructive = true
+		}
+		if ok {
+			columns = append(columns, ddl.Quote(d, b.Name))
+		}
+	}
+	copyRows := fmt.Sprintf(`INSERT INTO %s (%s) SELECT %s FROM %s`,
+		ddl.TableName(d, tmp), strings.Join(columns, ", "), strings.Join(columns, ", "), ddl.TableName(d, before))
+
+	g.alters = append(g.alters,
+		Statement{SQL: ddl.CreateTable(d, tmp)},
+		Statement{SQL: copyRows},
+		Statement{SQL: ddl.DropTable(d, before), Destructive: destructive},
+		Statement{SQL: rename},
+	)
+	for _, idx := range ddl.Indexes(d, after) {
+		g.alters = append(g.alters, Statement{SQL: ddl.CreateIndex(d, after, idx)})
+	}
+	return nil
+}
+
+// alter alters the table by the changes.
+func (g *generator) alter(before, after schema.Schema, changes diff.Changes) error {
+	d := g.dialect
+	var dropPrimaryKey, columns, addPrimaryKey []Statement
+	for _, c := range changes {
+		switch c.Object {
+		case diff.ObjectColumn:
+			b, _ := before.Column(c.Name)
+			a, _ := after.Column(c.Name)
+			switch c.Kind {
+			case diff.KindAdded:
+				columns = append(columns, Statement{SQL: ddl.AddColumn(d, after, a)})
+			case diff.KindRemoved:
+				columns = append(columns, Statement{SQL: ddl.DropColumn(d, after, b.Name), Destructive: true})
+			case diff.KindModified:
+				stmts, err := ddl.AlterColumn(d, after, b, a)
+				if err != nil {
+					return err
+				}
+				for _, stmt := range stmts {
+					columns = append(columns, Statement{SQL: stmt, Destructive: a.Type != b.Type})
+				}
+			}
+		case diff.ObjectPrimaryKey:
+			if c.Kind != diff.KindAdded {
+				stmt, err := ddl.DropPrimaryKey(d, before)
+				if err != nil {
+					return err
+				}
+				dropPrimaryKey = append(dropPrimaryKey, Statement{SQL: stmt})
+			}
+			if c.Kind != diff.KindRemoved {
+				stmt, err := ddl.AddPrimaryKey(d, after)
+				if err != nil {
+					return err
+				}
+				addPrimaryKey = append(addPrimaryKey, Statement{SQL: stmt})
+			}
+		case diff.ObjectForeignKey:
+			if c.Kind != diff.KindAdded {
+				fk, _ := lo.Find(before.ForeignKeys, func(fk schema.ForeignKey) bool { return fk.Name == c.Name })
+				if err := g.dropForeignKey(before, fk); err != nil {
+					return err
+				}
+			}
+			if c.Kind != diff.KindRemoved {
+				fk, _ := lo.Find(after.ForeignKeys, func(fk schema.ForeignKey) bool { return fk.Name == c.Name })
+				if err := g.addForeignKey(after, fk); err != nil {
+					return err
+				}
+			}
+		case diff.ObjectUniqueKey:
+			if c.Kind != diff.KindAdded {
+				uk, _ := lo.Find(before.UniqueKeys, func(uk schema.UniqueKey) bool { return uk.Name == c.Name })
+				if err := g.dropUniqueKey(before, uk); err != nil {
+					return err
+				}
+			}
+			if c.Kind != diff.KindRemoved {
+				uk, _ := lo.Find(after.UniqueKeys, func(uk schema.UniqueKey) bool { return uk.Name == c.Name })
+				if err := g.addUniqueKey(after, uk); err != nil {
+					return err
+				}
+			}
+		case diff.ObjectIndex:
+			if idx, ok := lo.Find(ddl.Indexes(d, before), func(idx schema.Index) bool { return idx.Name == c.Name }); ok && c.Kind != diff.KindAdded {
+				g.dropIndexes = append(g.dropIndexes, Statement{SQL: ddl.DropIndex(d, before, idx)})
+			}
+			if idx, ok := lo.Find(ddl.Indexes(d, after), func(idx schema.Index) bool { return idx.Name == c.Name }); ok && c.Kind != diff.KindRemoved {
+				g.creates = append(g.creates, Statement{SQL: ddl.CreateIndex(d, after, idx)})
+			}
+		}
+	}
+	g.alters = lo.Flatten([][]Statement{g.alters, dropPrimaryKey, columns, addPrimaryKey})
+	return nil
+}
+
+func (g *generator) dropForeignKey(t schema.Schema, fk schema.ForeignKey) error {
+	stmt, err := ddl.DropForeignKey(g.dialect, t, fk)
+	if err != nil {
+		return err
+	}
+	g.dropForeignKeys = append(g.dropForeignKeys, Statement{SQL: stmt})
+	return nil
+}
+
+func (g *generator) addForeignKey(t schema.Schema, fk schema.ForeignKey) error {
+	stmt, err := ddl.AddForeignKey(g.dialect, t, fk)
+	if err != nil {
+		return err
+	}
+	g.creates = append(g.creates, Statement{SQL: stmt})
+	return nil
+}
+
+func (g *generator) dropUniqueKey(t schema.Schema, uk schema.UniqueKey) error {
+	stmt, err := ddl.DropUniqueKey(g.dialect, t, uk)
+	if err != nil {
+		return err
+	}
+	g.dropIndexes = append(g.dropIndexes, Statement{SQL: stmt})
+	return nil
+}
+
+func (g *generator) addUniqueKey(t schema.Schema, uk schema.UniqueKey) error {
+	stmt, err := ddl.AddUniqueKey(g.dialect, t, uk)
+	if err != nil {
+		return err
+	}
+	g.creates = append(g.creates, Statement{SQL: stmt})
+	return nil
+}
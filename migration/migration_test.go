@@ -0,0 +1,221 @@
+package migration_test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Jumpaku/schenerate/migration"
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/Jumpaku/schenerate/sqlite3"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+)
+
+func TestGenerate_Postgres(t *testing.T) {
+	groups := schema.Schema{
+		Dialect: schema.DialectPostgres, Schema: "public", Name: "groups",
+		Columns:    []schema.Column{{Name: "id", Type: "integer"}},
+		PrimaryKey: []string{"id"},
+	}
+	users := schema.Schema{
+		Dialect: schema.DialectPostgres, Schema: "public", Name: "users",
+		Columns: []schema.Column{
+			{Name: "id", Type: "integer"},
+			{Name: "name", Type: "text", Nullable: true},
+			{Name: "note", Type: "text", Nullable: true},
+		},
+		PrimaryKey: []string{"id"},
+		Indexes:    []schema.Index{{Name: "idx_users_name", Key: []schema.IndexKeyElem{{Name: "name"}}}},
+	}
+	usersAfter := schema.Schema{
+		Dialect: schema.DialectPostgres, Schema: "public", Name: "users",
+		Columns: []schema.Column{
+			{Name: "id", Type: "integer"},
+			{Name: "name", Type: "character varying"},
+			{Name: "group_id", Type: "integer", Nullable: true},
+		},
+		PrimaryKey: []string{"id"},
+		ForeignKeys: []schema.ForeignKey{
+			{Name: "fk_users_groups", Key: []string{"group_id"}, Reference: schema.ForeignKeyReference{Schema: "public", Table: "groups", Key: []string{"id"}}},
+		},
+		UniqueKeys: []schema.UniqueKey{{Name: "uq_users_name", Key: []string{"name"}}},
+		Indexes: []schema.Index{
+			{Name: "idx_users_name", Unique: true, Key: []schema.IndexKeyElem{{Name: "name"}}},
+			{Name: "uq_users_name", Unique: true, Key: []schema.IndexKeyElem{{Name: "name"}}},
+		},
+	}
+
+	got, err := migration.Generate(schema.Schemas{users}, schema.Schemas{usersAfter, groups})
+
+	require.Nil(t, err)
+	require.Equal(t, []migration.Statement{
+		{SQL: `DROP INDEX "public"."idx_users_name"`},
+		{SQL: "CREATE TABLE \"public\".\"groups\" (\n  \"id\" integer NOT NULL,\n  PRIMARY KEY (\"id\")\n)"},
+		{SQL: `ALTER TABLE "public"."users" ALTER COLUMN "name" TYPE character varying`, Destructive: true},
+		{SQL: `ALTER TABLE "public"."users" ALTER COLUMN "name" SET NOT NULL`, Destructive: true},
+		{SQL: `ALTER TABLE "public"."users" DROP COLUMN "note"`, Destructive: true},
+		{SQL: `ALTER TABLE "public"."users" ADD COLUMN "group_id" integer`},
+		{SQL: `ALTER TABLE "public"."users" ADD CONSTRAINT "fk_users_groups" FOREIGN KEY ("group_id") REFERENCES "public"."groups" ("id")`},
+		{SQL: `ALTER TABLE "public"."users" ADD CONSTRAINT "uq_users_name" UNIQUE ("name")`},
+		{SQL: `CREATE UNIQUE INDEX "idx_users_name" ON "public"."users" ("name")`},
+	}, got.Statements)
+	require.True(t, got.Destructive())
+}
+
+func TestGenerate_Spanner(t *testing.T) {
+	parent := schema.Schema{
+		Dialect: schema.DialectSpanner, Name: "Parent", Type: "BASE TABLE",
+		Columns:    []schema.Column{{Name: "P", Type: "INT64"}},
+		PrimaryKey: []string{"P"},
+	}
+	child := schema.Schema{
+		Dialect: schema.DialectSpanner, Name: "Child", Type: "BASE TABLE",
+		Columns:    []schema.Column{{Name: "P", Type: "INT64"}, {Name: "C", Type: "INT64"}},
+		PrimaryKey: []string{"P", "C"},
+		Indexes:    []schema.Index{{Name: "IDX_Child_C", Key: []schema.IndexKeyElem{{Name: "C"}}}},
+	}
+	childAfter := child
+	childAfter.Parent = "Parent"
+
+	t.Run("create", func(t *testing.T) {
+		got, err := migration.Generate(nil, schema.Schemas{childAfter, parent})
+
+		require.Nil(t, err)
+		require.Equal(t, []migration.Statement{
+			{SQL: "CREATE TABLE `Parent` (\n  `P` INT64 NOT NULL\n) PRIMARY KEY (`P`)"},
+			{SQL: "CREATE TABLE `Child` (\n  `P` INT64 NOT NULL,\n  `C` INT64 NOT NULL\n) PRIMARY KEY (`P`, `C`),\n  INTERLEAVE IN PARENT `Parent`"},
+			{SQL: "CREATE INDEX `IDX_Child_C` ON `Child` (`C`)"},
+		}, got.Statements)
+		require.False(t, got.Destructive())
+	})
+	t.Run("recreate", func(t *testing.T) {
+		got, err := migration.Generate(schema.Schemas{parent, child}, schema.Schemas{parent, childAfter})
+
+		require.Nil(t, err)
+		require.Equal(t, []migration.Statement{
+			{SQL: "DROP INDEX `IDX_Child_C`"},
+			{SQL: "DROP TABLE `Child`", Destructive: true},
+			{SQL: "CREATE TABLE `Child` (\n  `P` INT64 NOT NULL,\n  `C` INT64 NOT NULL\n) PRIMARY KEY (`P`, `C`),\n  INTERLEAVE IN PARENT `Parent`"},
+			{SQL: "CREATE INDEX `IDX_Child_C` ON `Child` (`C`)"},
+		}, got.Statements)
+	})
+	t.Run("recreate referenced", func(t *testing.T) {
+		ref := schema.Schema{
+			Dialect: schema.DialectSpanner, Name: "Ref", Type: "BASE TABLE",
+			Columns:    []schema.Column{{Name: "R", Type: "INT64"}, {Name: "P", Type: "INT64"}, {Name: "C", Type: "INT64"}},
+			PrimaryKey: []string{"R"},
+			ForeignKeys: []schema.ForeignKey{
+				{Name: "FK_Ref_Child", Key: []string{"P", "C"}, Reference: schema.ForeignKeyReference{Table: "Child", Key: []string{"P", "C"}}},
+			},
+		}
+		got, err := migration.Generate(schema.Schemas{parent, child, ref}, schema.Schemas{parent, childAfter, ref})
+
+		require.Nil(t, err)
+		require.Equal(t, []migration.Statement{
+			{SQL: "ALTER TABLE `Ref` DROP CONSTRAINT `FK_Ref_Child`"},
+			{SQL: "DROP INDEX `IDX_Child_C`"},
+			{SQL: "DROP TABLE `Child`", Destructive: true},
+			{SQL: "CREATE TABLE `Child` (\n  `P` INT64 NOT NULL,\n  `C` INT64 NOT NULL\n) PRIMARY KEY (`P`, `C`),\n  INTERLEAVE IN PARENT `Parent`"},
+			{SQL: "ALTER TABLE `Ref` ADD CONSTRAINT `FK_Ref_Child` FOREIGN KEY (`P`, `C`) REFERENCES `Child` (`P`, `C`)"},
+			{SQL: "CREATE INDEX `IDX_Child_C` ON `Child` (`C`)"},
+		}, got.Statements)
+	})
+	t.Run("drop", func(t *testing.T) {
+		got, err := migration.Generate(schema.Schemas{parent, childAfter}, nil)
+
+		require.Nil(t, err)
+		require.Equal(t, []migration.Statement{
+			{SQL: "DROP INDEX `IDX_Child_C`"},
+			{SQL: "DROP TABLE `Child`", Destructive: true},
+			{SQL: "DROP TABLE `Parent`", Destructive: true},
+		}, got.Statements)
+	})
+}
+
+func TestGenerate_SQLite3(t *testing.T) {
+	testcases := []struct {
+		name            string
+		before          []string
+		after           []string
+		wantDestructive bool
+	}{
+		{
+			name:   "create tables with cyclic foreign keys",
+			before: nil,
+			after: []string{
+				`CREATE TABLE A (ID INTEGER PRIMARY KEY, BID INTEGER REFERENCES B (ID))`,
+				`CREATE TABLE B (ID INTEGER PRIMARY KEY, AID INTEGER REFERENCES A (ID))`,
+				`CREATE INDEX IDX_A_BID ON A (BID)`,
+			},
+		},
+		{
+			name:   "add nullable column and index",
+			before: []string{`CREATE TABLE A (ID INTEGER PRIMARY KEY)`},
+			after: []string{
+				`CREATE TABLE A (ID INTEGER PRIMARY KEY, Name TEXT)`,
+				`CREATE INDEX IDX_A_Name ON A (Name DESC)`,
+			},
+		},
+		{
+			name:            "rebuild",
+			before:          []string{`CREATE TABLE A (ID INTEGER PRIMARY KEY, Name TEXT, Note TEXT)`, `CREATE INDEX IDX_A_Name ON A (Name)`},
+			after:           []string{`CREATE TABLE A (ID INTEGER PRIMARY KEY, Name TEXT NOT NULL UNIQUE)`, `CREATE INDEX IDX_A_Name ON A (Name)`},
+			wantDestructive: true,
+		},
+		{
+			name:            "drop table",
+			before:          []string{`CREATE TABLE A (ID INTEGER PRIMARY KEY)`, `CREATE TABLE B (ID INTEGER PRIMARY KEY)`},
+			after:           []string{`CREATE TABLE A (ID INTEGER PRIMARY KEY)`},
+			wantDestructive: true,
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			dbPath := filepath.Join(t.TempDir(), "before.sqlite")
+			before, beforeDB := setupSQLite3(t, dbPath, testcase.before)
+			after, _ := setupSQLite3(t, filepath.Join(t.TempDir(), "after.sqlite"), testcase.after)
+
+			got, err := migration.Generate(before, after)
+			require.Nil(t, err)
+			require.Equal(t, testcase.wantDestructive, got.Destructive())
+
+			for _, stmt := range got.Statements {
+				_, err := beforeDB.Exec(stmt.SQL)
+				require.Nilf(t, err, "statement: %s", stmt.SQL)
+			}
+			migrated := listSQLite3(t, dbPath)
+			require.ElementsMatch(t, after, migrated)
+		})
+	}
+}
+
+func setupSQLite3(t *testing.T, dbPath string, ddls []string) (schema.Schemas, *sql.DB) {
+	t.Helper()
+	db, err := sql.Open("sqlite3", dbPath)
+	require.Nil(t, err)
+	t.Cleanup(func() { db.Close() })
+	db.SetMaxOpenConns(1)
+	for _, ddl := range ddls {
+		_, err := db.Exec(ddl)
+		require.Nil(t, err)
+	}
+	return listSQLite3(t, dbPath), db
+}
+
+func listSQLite3(t *testing.T, dbPath string) schema.Schemas {
+	t.Helper()
+	q, err := sqlite3.Open(dbPath)
+	require.Nil(t, err)
+	defer q.Close()
+	tables, err := q.ListTables(context.Background())
+	require.Nil(t, err)
+	var names []string
+	for _, table := range tables {
+		if table.Schema == "main" && table.Name != "sqlite_schema" {
+			names = append(names, table.Name)
+		}
+	}
+	schemas, err := q.ListSchemas(context.Background(), names)
+	require.Nil(t, err)
+	return schemas
+}
@@ -0,0 +1,163 @@
+package migrations
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Format is a naming convention of migration files.
+type Format string
+
+const (
+	// FormatGolangMigrate is the convention of golang-migrate, i.e. <version>_<description>.up.sql.
+	FormatGolangMigrate Format = "golang-migrate"
+	// FormatGoose is the convention of goose, i.e. <version>_<description>.sql with -- +goose Up and -- +goose Down sections.
+	FormatGoose Format = "goose"
+	// FormatFlyway is the convention of Flyway versioned migrations, i.e. V<version>__<description>.sql.
+	FormatFlyway Format = "flyway"
+)
+
+// Migration is an up-migration.
+type Migration struct {
+	Version     string
+	Description string
+	Format      Format
+	Path        string
+	// Up is the SQL statements migrating a database up to the version.
+	Up string
+}
+
+// Migrations is a sequence of up-migrations ordered by their versions.
+type Migrations []Migration
+
+var (
+	golangMigratePattern = regexp.MustCompile(`^(\d+)_(.*)\.up\.sql$`)
+	gooseIgnorePattern   = regexp.MustCompile(`\.(up|down)\.sql$`)
+	goosePattern         = regexp.MustCompile(`^(\d+)_(.*)\.sql$`)
+	flywayPattern        = regexp.MustCompile(`^V(\d+(?:[._]\d+)*)__(.*)\.sql$`)
+)
+
+// Load discovers the up-migrations in the directory and orders them by their versions.
+// Files not following any of the conventions, such as down-migrations and Flyway repeatable or undo migrations, are ignored.
+// It fails if the directory mixes the conventions or has duplicated versions.
+func Load(dir string) (Migrations, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to read directory %s: %w`, dir, err)
+	}
+
+	var migrations Migrations
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		m, ok := parseFileName(entry.Name())
+		if !ok {
+			continue
+		}
+		m.Path = filepath.Join(dir, entry.Name())
+		b, err := os.ReadFile(m.Path)
+		if err != nil {
+			return nil, fmt.Errorf(`fail to read migration %s: %w`, m.Path, err)
+		}
+		m.Up = string(b)
+		if m.Format == FormatGoose {
+			if m.Up, err = gooseUp(m.Up); err != nil {
+				return nil, fmt.Errorf(`fail to read migration %s: %w`, m.Path, err)
+			}
+		}
+		migrations = append(migrations, m)
+	}
+
+	slices.SortStableFunc(migrations, func(a, b Migration) int { return CompareVersions(a.Version, b.Version) })
+	for i, m := range migrations {
+		if m.Format != migrations[0].Format {
+			return nil, fmt.Errorf(`fail to load migrations: %s is %s but %s is %s`, m.Path, m.Format, migrations[0].Path, migrations[0].Format)
+		}
+		if i > 0 && CompareVersions(migrations[i-1].Version, m.Version) == 0 {
+			return nil, fmt.Errorf(`fail to load migrations: version %s is duplicated in %s and %s`, m.Version, migrations[i-1].Path, m.Path)
+		}
+	}
+	return migrations, nil
+}
+
+func parseFileName(name string) (Migration, bool) {
+	if m := golangMigratePattern.FindStringSubmatch(name); m != nil {
+		return Migration{Version: m[1], Description: m[2], Format: FormatGolangMigrate}, true
+	}
+	if m := flywayPattern.FindStringSubmatch(name); m != nil {
+		return Migration{Version: strings.ReplaceAll(m[1], "_", "."), Description: m[2], Format: FormatFlyway}, true
+	}
+	if m := goosePattern.FindStringSubmatch(name); m != nil && !gooseIgnorePattern.MatchString(name) {
+		return Migration{Version: m[1], Description: m[2], Format: FormatGoose}, true
+	}
+	return Migration{}, false
+}
+
+// gooseUp extracts the statements between -- +goose Up and -- +goose Down.
+func gooseUp(content string) (string, error) {
+	var up []string
+	inUp, found := false, false
+	for _, line := range strings.Split(content, "\n") {
+		annotation, isAnnotation := strings.CutPrefix(strings.TrimSpace(line), "-- +goose ")
+		switch {
+		case isAnnotation && strings.EqualFold(strings.TrimSpace(annotation), "Up"):
+			inUp, found = true, true
+		case isAnnotation && strings.EqualFold(strings.TrimSpace(annotation), "Down"):
+			inUp = false
+		case inUp:
+			up = append(up, line)
+		}
+	}
+	if !found {
+		return "", fmt.Errorf(`-- +goose Up annotation not found`)
+	}
+	return strings.Join(up, "\n"), nil
+}
+
+// UpTo returns the migrations up to and including the version, or all the migrations if the version is empty.
+func (ms Migrations) UpTo(version string) (Migrations, error) {
+	if version == "" {
+		return ms, nil
+	}
+	i := slices.IndexFunc(ms, func(m Migration) bool { return CompareVersions(m.Version, version) == 0 })
+	if i < 0 {
+		return nil, fmt.Errorf(`version %s not found`, version)
+	}
+	return ms[:i+1], nil
+}
+
+// Up returns the SQL statements of the migrations in order.
+func (ms Migrations) Up() []string {
+	up := make([]string, len(ms))
+	for i, m := range ms {
+		up[i] = m.Up
+	}
+	return up
+}
+
+// CompareVersions compares the versions numerically by their dot-separated parts, where missing parts are regarded as 0.
+func CompareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < max(len(as), len(bs)); i++ {
+		var x, y string
+		if i < len(as) {
+			x = strings.TrimLeft(as[i], "0")
+		}
+		if i < len(bs) {
+			y = strings.TrimLeft(bs[i], "0")
+		}
+		if c := cmp.Compare(len(x), len(y)); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(x, y); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
@@ -0,0 +1,151 @@
+package migrations_test
+
+import (
+	"github.com/Jumpaku/schenerate/migrations"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		require.Nil(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestLoad(t *testing.T) {
+	type migration struct {
+		Version     string
+		Description string
+		Format      migrations.Format
+		Up          string
+	}
+	testcases := []struct {
+		name    string
+		files   map[string]string
+		want    []migration
+		wantErr bool
+	}{
+		{
+			name: "golang-migrate",
+			files: map[string]string{
+				"10_c.up.sql":     "C",
+				"2_b.up.sql":      "B",
+				"2_b.down.sql":    "not B",
+				"000001_a.up.sql": "A",
+				"README.md":       "readme",
+			},
+			want: []migration{
+				{Version: "000001", Description: "a", Format: migrations.FormatGolangMigrate, Up: "A"},
+				{Version: "2", Description: "b", Format: migrations.FormatGolangMigrate, Up: "B"},
+				{Version: "10", Description: "c", Format: migrations.FormatGolangMigrate, Up: "C"},
+			},
+		},
+		{
+			name: "goose",
+			files: map[string]string{
+				"20240102000000_b.sql": "-- +goose Up\n-- +goose StatementBegin\nB;\n-- +goose StatementEnd\n-- +goose Down\nnot B;\n",
+				"20240101000000_a.sql": "-- +goose Up\nA;\n",
+			},
+			want: []migration{
+				{Version: "20240101000000", Description: "a", Format: migrations.FormatGoose, Up: "A;\n"},
+				{Version: "20240102000000", Description: "b", Format: migrations.FormatGoose, Up: "-- +goose StatementBegin\nB;\n-- +goose StatementEnd"},
+			},
+		},
+		{
+			name: "flyway",
+			files: map[string]string{
+				"V1_10__c.sql": "C",
+				"V1.2__b.sql":  "B",
+				"V1__a.sql":    "A",
+				"R__view.sql":  "repeatable",
+				"U1__a.sql":    "undo",
+			},
+			want: []migration{
+				{Version: "1", Description: "a", Format: migrations.FormatFlyway, Up: "A"},
+				{Version: "1.2", Description: "b", Format: migrations.FormatFlyway, Up: "B"},
+				{Version: "1.10", Description: "c", Format: migrations.FormatFlyway, Up: "C"},
+			},
+		},
+		{
+			name:    "goose without annotation",
+			files:   map[string]string{"1_a.sql": "A;"},
+			wantErr: true,
+		},
+		{
+			name:    "mixed formats",
+			files:   map[string]string{"1_a.up.sql": "A", "V2__b.sql": "B"},
+			wantErr: true,
+		},
+		{
+			name:    "duplicated versions",
+			files:   map[string]string{"1_a.up.sql": "A", "01_b.up.sql": "B"},
+			wantErr: true,
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			dir := writeFiles(t, testcase.files)
+
+			got, err := migrations.Load(dir)
+			if testcase.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, testcase.want, lo.Map(got, func(m migrations.Migration, _ int) migration {
+				return migration{Version: m.Version, Description: m.Description, Format: m.Format, Up: m.Up}
+			}))
+		})
+	}
+}
+
+func TestMigrations_UpTo(t *testing.T) {
+	ms := migrations.Migrations{{Version: "1", Up: "A"}, {Version: "2", Up: "B"}, {Version: "3", Up: "C"}}
+	testcases := []struct {
+		name    string
+		version string
+		want    []string
+		wantErr bool
+	}{
+		{name: "all", version: "", want: []string{"A", "B", "C"}},
+		{name: "first", version: "1", want: []string{"A"}},
+		{name: "middle", version: "02", want: []string{"A", "B"}},
+		{name: "not found", version: "4", wantErr: true},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			got, err := ms.UpTo(testcase.version)
+			if testcase.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, testcase.want, got.Up())
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	testcases := []struct {
+		a, b string
+		want int
+	}{
+		{a: "1", b: "2", want: -1},
+		{a: "10", b: "9", want: 1},
+		{a: "001", b: "1", want: 0},
+		{a: "1.0", b: "1", want: 0},
+		{a: "1.2", b: "1.10", want: -1},
+		{a: "20240101000000", b: "20231231235959", want: 1},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.a+"_"+testcase.b, func(t *testing.T) {
+			require.Equal(t, testcase.want, migrations.CompareVersions(testcase.a, testcase.b))
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package name
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// FuncMap returns the functions for text/template that expose case conversions, pluralization, escaping and joining of names.
+// Each function accepts a Name, a string or a fmt.Stringer as a name.
+//
+//   - name: converts the argument into a Name.
+//   - upperCamel (pascal), lowerCamel (camel), lowerSnake (snake), allUpperSnake (screamingSnake), firstUpperSnake, lowerKebab (kebab), allUpperKebab, firstUpperKebab: convert the case.
+//   - plural, singular: inflect the last word.
+//   - append, prepend: add words, e.g. {{.Table | append "id"}}.
+//   - joinWords: joins words with a separator, a prefix and a suffix, e.g. {{.Table | joinWords "." "<" ">"}}.
+//   - join: joins elements of a slice with a separator, e.g. {{join ", " .Columns}}.
+//   - shorten: shortens to a maximum length in bytes, e.g. {{.Index | shorten 63}}.
+//   - quote, quotePostgres, quoteSQLite3, quoteSpanner: quote as an identifier, e.g. {{.Table | quote "postgres"}}.
+func FuncMap() template.FuncMap {
+	conv := func(f func(n Name) string) func(v any) string {
+		return func(v any) string { return f(toName(v)) }
+	}
+	return template.FuncMap{
+		"name":            toName,
+		"upperCamel":      conv(Name.UpperCamel),
+		"pascal":          conv(Name.UpperCamel),
+		"lowerCamel":      conv(Name.LowerCamel),
+		"camel":           conv(Name.LowerCamel),
+		"lowerSnake":      conv(Name.LowerSnake),
+		"snake":           conv(Name.LowerSnake),
+		"allUpperSnake":   conv(Name.AllUpperSnake),
+		"screamingSnake":  conv(Name.AllUpperSnake),
+		"firstUpperSnake": conv(Name.FirstUpperSnake),
+		"lowerKebab":      conv(Name.LowerKebab),
+		"kebab":           conv(Name.LowerKebab),
+		"allUpperKebab":   conv(Name.AllUpperKebab),
+		"firstUpperKebab": conv(Name.FirstUpperKebab),
+		"plural":          func(v any) Name { return toName(v).Plural() },
+		"singular":        func(v any) Name { return toName(v).Singular() },
+		"append":          func(s string, v any) Name { return toName(v).Append(s) },
+		"prepend":         func(s string, v any) Name { return toName(v).Prepend(s) },
+		"joinWords":       func(sep, prefix, suffix string, v any) string { return toName(v).Join(sep, prefix, suffix) },
+		"join":            join,
+		"shorten":         func(maxLen int, v any) string { return Shorten(toString(v), maxLen) },
+		"quote":           func(dialect string, v any) string { return Quote(Dialect(dialect), toString(v)) },
+		"quotePostgres":   func(v any) string { return Quote(DialectPostgres, toString(v)) },
+		"quoteSQLite3":    func(v any) string { return Quote(DialectSQLite3, toString(v)) },
+		"quoteSpanner":    func(v any) string { return Quote(DialectSpanner, toString(v)) },
+	}
+}
+
+// FormatPath executes pattern as a text/template with FuncMap and data to build a file path, e.g. "{{.Table | snake}}_repository.go".
+// The functions of funcs are also available, which override the functions of FuncMap of the same names.
+func FormatPath(pattern string, data any, funcs ...template.FuncMap) (string, error) {
+	t := template.New("path").Funcs(FuncMap())
+	for _, f := range funcs {
+		t = t.Funcs(f)
+	}
+	t, err := t.Option("missingkey=error").Parse(pattern)
+	if err != nil {
+		return "", fmt.Errorf(`fail to parse path pattern %q: %w`, pattern, err)
+	}
+	var b bytes.Buffer
+	if err := t.Execute(&b, data); err != nil {
+		return "", fmt.Errorf(`fail to format path pattern %q: %w`, pattern, err)
+	}
+	return b.String(), nil
+}
+
+func toName(v any) Name {
+	if n, ok := v.(Name); ok {
+		return n
+	}
+	return New(toString(v))
+}
+
+func toString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func join(sep string, elems any) (string, error) {
+	rv := reflect.ValueOf(elems)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return "", fmt.Errorf(`join: slice expected but got %T`, elems)
+	}
+	ss := make([]string, rv.Len())
+	for i := range ss {
+		ss[i] = toString(rv.Index(i).Interface())
+	}
+	return strings.Join(ss, sep), nil
+}
@@ -0,0 +1,65 @@
+package name_test
+
+import (
+	"bytes"
+	"github.com/Jumpaku/schenerate/name"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestFuncMap(t *testing.T) {
+	tests := []struct {
+		name string
+		tmpl string
+		want string
+	}{
+		{name: "upperCamel", tmpl: `{{.Table | upperCamel}}`, want: "OrderItem"},
+		{name: "pascal", tmpl: `{{.Table | pascal}}`, want: "OrderItem"},
+		{name: "lowerCamel", tmpl: `{{.Table | lowerCamel}}`, want: "orderItem"},
+		{name: "camel", tmpl: `{{.Table | camel}}`, want: "orderItem"},
+		{name: "snake", tmpl: `{{.Table | snake}}`, want: "order_item"},
+		{name: "allUpperSnake", tmpl: `{{.Table | allUpperSnake}}`, want: "ORDER_ITEM"},
+		{name: "firstUpperSnake", tmpl: `{{.Table | firstUpperSnake}}`, want: "Order_Item"},
+		{name: "kebab", tmpl: `{{.Table | kebab}}`, want: "order-item"},
+		{name: "allUpperKebab", tmpl: `{{.Table | allUpperKebab}}`, want: "ORDER-ITEM"},
+		{name: "firstUpperKebab", tmpl: `{{.Table | firstUpperKebab}}`, want: "Order-Item"},
+		{name: "plural", tmpl: `{{.Table | plural | upperCamel}}`, want: "OrderItems"},
+		{name: "singular", tmpl: `{{"categories" | singular}}`, want: "category"},
+		{name: "append", tmpl: `{{.Table | append "id" | snake}}`, want: "order_item_id"},
+		{name: "prepend", tmpl: `{{.Table | prepend "new" | camel}}`, want: "newOrderItem"},
+		{name: "joinWords", tmpl: `{{.Table | joinWords "." "<" ">"}}`, want: "<Order.Item>"},
+		{name: "join", tmpl: `{{join ", " .Columns}}`, want: "id, name"},
+		{name: "shorten", tmpl: `{{"uq_users_email_address" | shorten 20}}`, want: "uq_usrs_eml_46d05f6e"},
+		{name: "quote", tmpl: `{{.Table | quote "spanner"}}`, want: "`OrderItem`"},
+		{name: "quotePostgres", tmpl: `{{.Table | quotePostgres}}`, want: `"OrderItem"`},
+		{name: "quoteSQLite3", tmpl: `{{.Table | quoteSQLite3}}`, want: `[OrderItem]`},
+		{name: "quoteSpanner", tmpl: `{{.Table | quoteSpanner}}`, want: "`OrderItem`"},
+		{name: "name", tmpl: `{{(name .Table).Len}}`, want: "2"},
+	}
+	data := map[string]any{"Table": "OrderItem", "Columns": []string{"id", "name"}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := template.New("").Funcs(name.FuncMap()).Parse(tt.tmpl)
+			require.NoError(t, err)
+			var b bytes.Buffer
+			require.NoError(t, tmpl.Execute(&b, data))
+			assert.Equal(t, tt.want, b.String())
+		})
+	}
+}
+
+func TestFormatPath(t *testing.T) {
+	got, err := name.FormatPath(`{{.Table | snake}}_repository.go`, map[string]string{"Table": "OrderItem"})
+	require.NoError(t, err)
+	assert.Equal(t, "order_item_repository.go", got)
+
+	_, err = name.FormatPath(`{{.Missing | snake}}.go`, map[string]string{"Table": "OrderItem"})
+	require.Error(t, err)
+
+	got, err = name.FormatPath(`{{.Table | snake}}.go`, map[string]string{"Table": "OrderItem"}, template.FuncMap{"snake": strings.ToUpper})
+	require.NoError(t, err)
+	assert.Equal(t, "ORDERITEM.go", got)
+}
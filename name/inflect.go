@@ -0,0 +1,137 @@
+package name
+
+import (
+	"strings"
+)
+
+var irregularPlurals = map[string]string{
+	"person": "people",
+	"child":  "children",
+	"man":    "men",
+	"woman":  "women",
+	"mouse":  "mice",
+	"goose":  "geese",
+	"foot":   "feet",
+	"tooth":  "teeth",
+	"datum":  "data",
+	"medium": "media",
+}
+
+var irregularSingulars = func() map[string]string {
+	m := map[string]string{}
+	for s, p := range irregularPlurals {
+		m[p] = s
+	}
+	return m
+}()
+
+var uncountables = map[string]bool{
+	"data": true, "metadata": true, "information": true, "equipment": true, "series": true,
+	"species": true, "news": true, "sheep": true, "fish": true, "deer": true,
+}
+
+// cheSingulars are singulars ending with "che", whose plurals are inflected by "s" unlike church and churches.
+var cheSingulars = []string{"cache", "niche", "avalanche", "ache", "cliche", "psyche", "fiche", "creche", "moustache", "mustache"}
+
+// Plural returns the name whose last word is pluralized by English rules.
+func (n Name) Plural() Name {
+	return n.inflectLast(pluralize)
+}
+
+// Singular returns the name whose last word is singularized by English rules.
+func (n Name) Singular() Name {
+	return n.inflectLast(singularize)
+}
+
+func (n Name) inflectLast(f func(w string) string) Name {
+	words := append([]string{}, n.words...)
+	for i := len(words) - 1; i >= 0; i-- {
+		if !n.isRemovable(words[i]) {
+			words[i] = matchCase(words[i], f(strings.ToLower(words[i])))
+			break
+		}
+	}
+	return Name{words: words, splitter: n.splitter}
+}
+
+func pluralize(w string) string {
+	if p, ok := irregularPlurals[w]; ok {
+		return p
+	}
+	if uncountables[w] || !isLowerRune(lastRune(w)) {
+		return w
+	}
+	switch {
+	case strings.HasSuffix(w, "sis"):
+		return strings.TrimSuffix(w, "is") + "es"
+	case hasAnySuffix(w, "s", "x", "z", "ch", "sh"):
+		return w + "es"
+	case strings.HasSuffix(w, "y") && len(w) > 1 && !strings.ContainsRune("aeiou", rune(w[len(w)-2])):
+		return strings.TrimSuffix(w, "y") + "ies"
+	default:
+		return w + "s"
+	}
+}
+
+func singularize(w string) string {
+	if s, ok := irregularSingulars[w]; ok {
+		return s
+	}
+	if uncountables[w] || !isLowerRune(lastRune(w)) {
+		return w
+	}
+	switch {
+	case strings.HasSuffix(w, "ies") && len(w) > 3:
+		return strings.TrimSuffix(w, "ies") + "y"
+	case strings.HasSuffix(w, "yses"):
+		return strings.TrimSuffix(w, "es") + "is"
+	case strings.HasSuffix(w, "ches") && hasAnySuffix(strings.TrimSuffix(w, "s"), cheSingulars...):
+		return strings.TrimSuffix(w, "s")
+	case strings.HasSuffix(w, "uses") && len(w) > 4 && !strings.ContainsRune("aeiou", rune(w[len(w)-5])):
+		// e.g. statuses and buses, but not houses and causes.
+		return strings.TrimSuffix(w, "es")
+	case hasAnySuffix(w, "sses", "xes", "zes", "ches", "shes"):
+		return strings.TrimSuffix(w, "es")
+	case hasAnySuffix(w, "ss", "us", "is"):
+		return w
+	case strings.HasSuffix(w, "s"):
+		return strings.TrimSuffix(w, "s")
+	default:
+		return w
+	}
+}
+
+// matchCase returns inflected with the case of the original word, which is assumed to share a prefix with inflected.
+func matchCase(original, inflected string) string {
+	if original == strings.ToLower(original) {
+		return inflected
+	}
+	upper := original == strings.ToUpper(original)
+	or, ir := []rune(original), []rune(inflected)
+	for i := range ir {
+		switch {
+		case i < len(or) && strings.EqualFold(string(or[i]), string(ir[i])):
+			ir[i] = or[i]
+		case upper:
+			ir[i] = []rune(strings.ToUpper(string(ir[i])))[0]
+		}
+	}
+	return string(ir)
+}
+
+func hasAnySuffix(w string, suffixes ...string) bool {
+	for _, s := range suffixes {
+		if strings.HasSuffix(w, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func lastRune(w string) rune {
+	rs := []rune(w)
+	if len(rs) == 0 {
+		return 0
+	}
+	return rs[len(rs)-1]
+}
@@ -0,0 +1,71 @@
+package name_test
+
+import (
+	"github.com/Jumpaku/schenerate/name"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestName_Plural(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "user", want: "users"},
+		{in: "order_item", want: "order_items"},
+		{in: "OrderItem", want: "OrderItems"},
+		{in: "ORDER_ITEM", want: "ORDER_ITEMS"},
+		{in: "status", want: "statuses"},
+		{in: "box", want: "boxes"},
+		{in: "branch", want: "branches"},
+		{in: "category", want: "categories"},
+		{in: "day", want: "days"},
+		{in: "analysis", want: "analyses"},
+		{in: "Person", want: "People"},
+		{in: "child", want: "children"},
+		{in: "metadata", want: "metadata"},
+		{in: "user_", want: "users_"},
+		{in: "item1", want: "item1"},
+		{in: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			assert.Equal(t, tt.want, name.New(tt.in).Plural().String())
+		})
+	}
+}
+
+func TestName_Singular(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "users", want: "user"},
+		{in: "order_items", want: "order_item"},
+		{in: "ORDER_ITEMS", want: "ORDER_ITEM"},
+		{in: "statuses", want: "status"},
+		{in: "status", want: "status"},
+		{in: "buses", want: "bus"},
+		{in: "courses", want: "course"},
+		{in: "houses", want: "house"},
+		{in: "warehouses", want: "warehouse"},
+		{in: "uses", want: "use"},
+		{in: "boxes", want: "box"},
+		{in: "branches", want: "branch"},
+		{in: "churches", want: "church"},
+		{in: "caches", want: "cache"},
+		{in: "avalanches", want: "avalanche"},
+		{in: "categories", want: "category"},
+		{in: "analyses", want: "analysis"},
+		{in: "addresses", want: "address"},
+		{in: "address", want: "address"},
+		{in: "People", want: "Person"},
+		{in: "news", want: "news"},
+		{in: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			assert.Equal(t, tt.want, name.New(tt.in).Singular().String())
+		})
+	}
+}
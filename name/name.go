@@ -2,48 +2,133 @@ package name
 
 import (
 	"github.com/samber/lo"
+	"slices"
 	"strings"
+	"unicode"
 )
 
 type Name struct {
-	words []string
+	words    []string
+	splitter *Splitter
 }
 
+// New splits s into words with the default Splitter.
 func New(s string) Name {
+	return Splitter{}.New(s)
+}
+
+// Splitter configures how a string is split into words.
+// The zero value is the default Splitter used by New.
+type Splitter struct {
+	// SplitDigits makes boundaries between letters and digits, e.g. "Col01" is split into "Col" and "01".
+	SplitDigits bool
+	// Separators is the runes that separate words.
+	// If it is empty, every rune other than ASCII letters and digits is a separator.
+	// Otherwise, the other runes are treated as caseless letters.
+	// Whitespaces are always separators.
+	Separators []rune
+	// Unsplittable is the words that are never split, e.g. "OAuth2" and "iOS".
+	Unsplittable []string
+	// PreserveCase is the words whose case is preserved by UpperCamel, LowerCamel, FirstUpperSnake and FirstUpperKebab.
+	// They are never split as well as the words in Unsplittable.
+	PreserveCase []string
+}
+
+// New splits s into words.
+func (sp Splitter) New(s string) Name {
 	rs := []rune(strings.Join(strings.Fields(s), " "))
 	ws := [][]rune{}
-	for i, r := range rs {
-		if i == 0 {
+	split := true
+	for i := 0; i < len(rs); i++ {
+		if w, ok := sp.unsplittablePrefix(rs, i); ok {
+			ws = append(ws, w)
+			i += len(w) - 1
+			split = true
+			continue
+		}
+		r := rs[i]
+		if split {
 			ws = append(ws, []rune{r})
+			split = false
 			continue
 		}
 		pr := rs[i-1]
 		switch {
+		case sp.isSeparator(r):
+			ws = append(ws, []rune{r})
 		case isUpperRune(r):
-			if isLowerRune(pr) || isDigitRune(pr) || isSymbolRune(pr) {
+			if !isUpperRune(pr) {
 				ws = append(ws, []rune{r})
 			} else {
 				ws[len(ws)-1] = append(ws[len(ws)-1], r)
 			}
-		case isLowerRune(r):
-			if isSymbolRune(pr) {
+		case isDigitRune(r):
+			if sp.isSeparator(pr) || (sp.SplitDigits && !isDigitRune(pr)) {
 				ws = append(ws, []rune{r})
 			} else {
 				ws[len(ws)-1] = append(ws[len(ws)-1], r)
 			}
-		case isDigitRune(r):
-			if isSymbolRune(pr) {
+		default:
+			if sp.isSeparator(pr) || (sp.SplitDigits && isDigitRune(pr)) {
 				ws = append(ws, []rune{r})
 			} else {
 				ws[len(ws)-1] = append(ws[len(ws)-1], r)
 			}
-		default:
-			ws = append(ws, []rune{r})
 		}
 	}
 	return Name{
-		words: lo.Map(ws, func(r []rune, _ int) string { return string(r) }),
+		words:    lo.Map(ws, func(r []rune, _ int) string { return string(r) }),
+		splitter: &sp,
+	}
+}
+
+func (sp Splitter) isSeparator(r rune) bool {
+	if len(sp.Separators) == 0 {
+		return isSymbolRune(r)
+	}
+	return unicode.IsSpace(r) || slices.Contains(sp.Separators, r)
+}
+
+// isBoundary reports whether a word can start at rs[i], i.e. at the start, after a separator, or at a case or digit transition.
+func (sp Splitter) isBoundary(rs []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+	r, pr := rs[i], rs[i-1]
+	return sp.isSeparator(pr) || (isUpperRune(r) && !isUpperRune(pr)) || isDigitRune(r) != isDigitRune(pr)
+}
+
+// isEndBoundary reports whether a word can end before rs[i], i.e. at the end, at a separator, or at a case or digit transition,
+// where an upper case rune followed by a lower case rune starts a word as "Device" of "iOSDevice".
+func (sp Splitter) isEndBoundary(rs []rune, i int) bool {
+	if i == len(rs) {
+		return true
+	}
+	r, pr := rs[i], rs[i-1]
+	if sp.isSeparator(r) || sp.isSeparator(pr) || isDigitRune(r) != isDigitRune(pr) {
+		return true
 	}
+	return isUpperRune(r) && (!isUpperRune(pr) || i+1 == len(rs) || isLowerRune(rs[i+1]))
+}
+
+// unsplittablePrefix returns the longest unsplittable word at rs[i] that is bounded at both ends.
+func (sp Splitter) unsplittablePrefix(rs []rune, i int) ([]rune, bool) {
+	if !sp.isBoundary(rs, i) {
+		return nil, false
+	}
+	var found []rune
+	for _, w := range append(append([]string{}, sp.Unsplittable...), sp.PreserveCase...) {
+		wr := []rune(w)
+		end := i + len(wr)
+		if len(wr) > len(found) && end <= len(rs) && string(rs[i:end]) == w && sp.isEndBoundary(rs, end) {
+			found = wr
+		}
+	}
+	return found, len(found) > 0
+}
+
+func (sp Splitter) preservesCase(w string) bool {
+	return slices.Contains(sp.PreserveCase, w)
 }
 
 func (n Name) String() string {
@@ -60,25 +145,29 @@ func (n Name) Join(sep, prefix, suffix string) string {
 
 func (n Name) Map(f func(w string) string) Name {
 	return Name{
-		words: lo.Map(n.words, func(w string, _ int) string { return f(w) }),
+		words:    lo.Map(n.words, func(w string, _ int) string { return f(w) }),
+		splitter: n.splitter,
 	}
 }
 
 func (n Name) Append(s string) Name {
 	return Name{
-		words: append(append([]string{}, n.words...), New(s).words...),
+		words:    append(append([]string{}, n.words...), n.getSplitter().New(s).words...),
+		splitter: n.splitter,
 	}
 }
 
 func (n Name) Prepend(s string) Name {
 	return Name{
-		words: append(New(s).words, n.words...),
+		words:    append(n.getSplitter().New(s).words, n.words...),
+		splitter: n.splitter,
 	}
 }
 
 func (n Name) RemoveIf(f func(w string) bool) Name {
 	return Name{
-		words: lo.Filter(n.words, func(w string, _ int) bool { return !f(w) }),
+		words:    lo.Filter(n.words, func(w string, _ int) bool { return !f(w) }),
+		splitter: n.splitter,
 	}
 }
 
@@ -87,11 +176,11 @@ func (n Name) Len() int {
 }
 
 func (n Name) Slice(begin, end int) Name {
-	return Name{words: n.words[begin:end]}
+	return Name{words: n.words[begin:end], splitter: n.splitter}
 }
 
 func (n Name) Get(i int) Name {
-	return Name{words: []string{n.words[i]}}
+	return Name{words: []string{n.words[i]}, splitter: n.splitter}
 }
 
 func (n Name) LowerCamel() string {
@@ -99,56 +188,59 @@ func (n Name) LowerCamel() string {
 	if u == "" {
 		return ""
 	}
+	if w, ok := lo.Find(n.words, func(w string) bool { return !n.isRemovable(w) }); ok && n.getSplitter().preservesCase(w) {
+		return u
+	}
 	rs := []rune(u)
 	return strings.ToLower(string(rs[0])) + string(rs[1:])
 }
 
 func (n Name) UpperCamel() string {
 	return n.
-		Map(toFirstUpper).
-		RemoveIf(isRemovable).
+		Map(n.toFirstUpper).
+		RemoveIf(n.isRemovable).
 		Join("", "", "")
 }
 
 func (n Name) LowerSnake() string {
 	return n.
 		Map(toLower).
-		RemoveIf(isRemovable).
+		RemoveIf(n.isRemovable).
 		Join("_", "", "")
 }
 
 func (n Name) AllUpperSnake() string {
 	return n.
 		Map(toAllUpper).
-		RemoveIf(isRemovable).
+		RemoveIf(n.isRemovable).
 		Join("_", "", "")
 }
 
 func (n Name) FirstUpperSnake() string {
 	return n.
-		Map(toFirstUpper).
-		RemoveIf(isRemovable).
+		Map(n.toFirstUpper).
+		RemoveIf(n.isRemovable).
 		Join("_", "", "")
 }
 
 func (n Name) LowerKebab() string {
 	return n.
 		Map(toLower).
-		RemoveIf(isRemovable).
+		RemoveIf(n.isRemovable).
 		Join("-", "", "")
 }
 
 func (n Name) FirstUpperKebab() string {
 	return n.
-		Map(toFirstUpper).
-		RemoveIf(isRemovable).
+		Map(n.toFirstUpper).
+		RemoveIf(n.isRemovable).
 		Join("-", "", "")
 }
 
 func (n Name) AllUpperKebab() string {
 	return n.
 		Map(toAllUpper).
-		RemoveIf(isRemovable).
+		RemoveIf(n.isRemovable).
 		Join("-", "", "")
 }
 
@@ -176,12 +268,22 @@ func toAllUpper(w string) string {
 	return strings.ToUpper(w)
 }
 
-func toFirstUpper(w string) string {
+func (n Name) toFirstUpper(w string) string {
+	if n.getSplitter().preservesCase(w) {
+		return w
+	}
 	rs := []rune(strings.ToLower(w))
 	return strings.ToUpper(string(rs[0])) + string(rs[1:])
 }
 
-func isRemovable(w string) bool {
+func (n Name) isRemovable(w string) bool {
 	rs := []rune(w)
-	return len(rs) == 0 || (len(rs) == 1 && isSymbolRune(rs[0]))
+	return len(rs) == 0 || (len(rs) == 1 && n.getSplitter().isSeparator(rs[0]))
+}
+
+func (n Name) getSplitter() Splitter {
+	if n.splitter == nil {
+		return Splitter{}
+	}
+	return *n.splitter
 }
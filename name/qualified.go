@@ -0,0 +1,189 @@
+package name
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dialect is a SQL dialect that determines how identifiers are quoted.
+type Dialect string
+
+const (
+	DialectPostgres Dialect = "postgres"
+	DialectSQLite3  Dialect = "sqlite3"
+	DialectSpanner  Dialect = "spanner"
+)
+
+// Qualified is a qualified identifier of a database object, which consists of catalog, schema, object and optionally column parts.
+// Empty parts are omitted.
+type Qualified struct {
+	Catalog string
+	Schema  string
+	Object  string
+	Column  string
+}
+
+// ParseQualified parses s in the form of [[catalog.]schema.]object, each part of which may be quoted in the dialect.
+// Unquoted parts are folded to lower case in Postgres.
+func ParseQualified(dialect Dialect, s string) (Qualified, error) {
+	parts, err := parseQualified(dialect, s)
+	if err != nil {
+		return Qualified{}, fmt.Errorf(`fail to parse qualified identifier %q: %w`, s, err)
+	}
+	if len(parts) > maxQualifiedParts(dialect) {
+		return Qualified{}, fmt.Errorf(`fail to parse qualified identifier %q: too many parts for %s`, s, dialect)
+	}
+	parts = append(make([]string, 3-len(parts)), parts...)
+	return Qualified{Catalog: parts[0], Schema: parts[1], Object: parts[2]}, nil
+}
+
+// ParseQualifiedColumn parses s in the form of [[[catalog.]schema.]object.]column, each part of which may be quoted in the dialect.
+// Unquoted parts are folded to lower case in Postgres.
+func ParseQualifiedColumn(dialect Dialect, s string) (Qualified, error) {
+	parts, err := parseQualified(dialect, s)
+	if err != nil {
+		return Qualified{}, fmt.Errorf(`fail to parse qualified column %q: %w`, s, err)
+	}
+	if len(parts) > maxQualifiedParts(dialect)+1 {
+		return Qualified{}, fmt.Errorf(`fail to parse qualified column %q: too many parts for %s`, s, dialect)
+	}
+	parts = append(make([]string, 4-len(parts)), parts...)
+	return Qualified{Catalog: parts[0], Schema: parts[1], Object: parts[2], Column: parts[3]}, nil
+}
+
+// Quote returns the qualified identifier with each part quoted in the dialect, e.g. "public"."User" in Postgres, `User` in Spanner and [main].[t] in SQLite.
+func (q Qualified) Quote(dialect Dialect) string {
+	var parts []string
+	for _, p := range []string{q.Catalog, q.Schema, q.Object, q.Column} {
+		if p != "" {
+			parts = append(parts, Quote(dialect, p))
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+func (q Qualified) String() string {
+	var parts []string
+	for _, p := range []string{q.Catalog, q.Schema, q.Object, q.Column} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+func (q Qualified) CatalogName() Name {
+	return New(q.Catalog)
+}
+
+func (q Qualified) SchemaName() Name {
+	return New(q.Schema)
+}
+
+func (q Qualified) ObjectName() Name {
+	return New(q.Object)
+}
+
+func (q Qualified) ColumnName() Name {
+	return New(q.Column)
+}
+
+// Quote returns the identifier quoted in the dialect.
+func Quote(dialect Dialect, ident string) string {
+	switch dialect {
+	case DialectSpanner:
+		return "`" + strings.NewReplacer(`\`, `\\`, "`", "\\`").Replace(ident) + "`"
+	case DialectSQLite3:
+		if !strings.Contains(ident, "]") {
+			return "[" + ident + "]"
+		}
+		fallthrough
+	default:
+		return `"` + strings.ReplaceAll(ident, `"`, `""`) + `"`
+	}
+}
+
+func maxQualifiedParts(dialect Dialect) int {
+	if dialect == DialectPostgres {
+		return 3
+	}
+	return 2
+}
+
+func parseQualified(dialect Dialect, s string) ([]string, error) {
+	rs := []rune(s)
+	var parts []string
+	for i := 0; ; i++ {
+		var part string
+		var err error
+		part, i, err = parseQualifiedPart(dialect, rs, i)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+		if i == len(rs) {
+			return parts, nil
+		}
+		if rs[i] != '.' {
+			return nil, fmt.Errorf(`unexpected %q at %d`, rs[i], i)
+		}
+	}
+}
+
+func parseQualifiedPart(dialect Dialect, rs []rune, begin int) (part string, end int, err error) {
+	if begin == len(rs) {
+		return "", 0, fmt.Errorf(`identifier expected at %d`, begin)
+	}
+	var closing rune
+	switch rs[begin] {
+	case '"':
+		if dialect == DialectSpanner {
+			return "", 0, fmt.Errorf(`unexpected %q at %d`, rs[begin], begin)
+		}
+		closing = '"'
+	case '`':
+		if dialect == DialectPostgres {
+			return "", 0, fmt.Errorf(`unexpected %q at %d`, rs[begin], begin)
+		}
+		closing = '`'
+	case '[':
+		if dialect != DialectSQLite3 {
+			return "", 0, fmt.Errorf(`unexpected %q at %d`, rs[begin], begin)
+		}
+		closing = ']'
+	default:
+		end = begin
+		for end < len(rs) && rs[end] != '.' {
+			end++
+		}
+		part = strings.TrimSpace(string(rs[begin:end]))
+		if part == "" {
+			return "", 0, fmt.Errorf(`identifier expected at %d`, begin)
+		}
+		if dialect == DialectPostgres {
+			part = strings.ToLower(part)
+		}
+		return part, end, nil
+	}
+
+	var b strings.Builder
+	for i := begin + 1; i < len(rs); i++ {
+		r := rs[i]
+		switch {
+		case dialect == DialectSpanner && r == '\\' && i+1 < len(rs):
+			i++
+			b.WriteRune(rs[i])
+		case r == closing && closing != ']' && dialect != DialectSpanner && i+1 < len(rs) && rs[i+1] == closing:
+			i++
+			b.WriteRune(r)
+		case r == closing:
+			if b.Len() == 0 {
+				return "", 0, fmt.Errorf(`empty identifier at %d`, begin)
+			}
+			return b.String(), i + 1, nil
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return "", 0, fmt.Errorf(`unterminated quoted identifier at %d`, begin)
+}
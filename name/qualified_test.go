@@ -0,0 +1,61 @@
+package name_test
+
+import (
+	"github.com/Jumpaku/schenerate/name"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseQualified(t *testing.T) {
+	tests := []struct {
+		name      string
+		dialect   name.Dialect
+		in        string
+		want      name.Qualified
+		wantQuote string
+		wantErr   bool
+	}{
+		{name: "postgres quoted", dialect: name.DialectPostgres, in: `"public"."User"`, want: name.Qualified{Schema: "public", Object: "User"}, wantQuote: `"public"."User"`},
+		{name: "postgres unquoted", dialect: name.DialectPostgres, in: `db.Public.User`, want: name.Qualified{Catalog: "db", Schema: "public", Object: "user"}, wantQuote: `"db"."public"."user"`},
+		{name: "postgres escaped", dialect: name.DialectPostgres, in: `"a""b"`, want: name.Qualified{Object: `a"b`}, wantQuote: `"a""b"`},
+		{name: "postgres dotted", dialect: name.DialectPostgres, in: `"a.b"`, want: name.Qualified{Object: `a.b`}, wantQuote: `"a.b"`},
+		{name: "postgres too many", dialect: name.DialectPostgres, in: `a.b.c.d`, wantErr: true},
+		{name: "postgres backtick", dialect: name.DialectPostgres, in: "`a`", wantErr: true},
+		{name: "spanner", dialect: name.DialectSpanner, in: "`User`", want: name.Qualified{Object: "User"}, wantQuote: "`User`"},
+		{name: "spanner named schema", dialect: name.DialectSpanner, in: "sch.`User`", want: name.Qualified{Schema: "sch", Object: "User"}, wantQuote: "`sch`.`User`"},
+		{name: "spanner escaped", dialect: name.DialectSpanner, in: "`a\\`b`", want: name.Qualified{Object: "a`b"}, wantQuote: "`a\\`b`"},
+		{name: "spanner too many", dialect: name.DialectSpanner, in: "a.b.c", wantErr: true},
+		{name: "sqlite brackets", dialect: name.DialectSQLite3, in: `[main].[t]`, want: name.Qualified{Schema: "main", Object: "t"}, wantQuote: `[main].[t]`},
+		{name: "sqlite mixed", dialect: name.DialectSQLite3, in: "\"main\".`T`", want: name.Qualified{Schema: "main", Object: "T"}, wantQuote: `[main].[T]`},
+		{name: "sqlite bracket in name", dialect: name.DialectSQLite3, in: `"a]b"`, want: name.Qualified{Object: "a]b"}, wantQuote: `"a]b"`},
+		{name: "empty", dialect: name.DialectSQLite3, in: ``, wantErr: true},
+		{name: "trailing dot", dialect: name.DialectSQLite3, in: `a.`, wantErr: true},
+		{name: "unterminated", dialect: name.DialectSQLite3, in: `[a`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := name.ParseQualified(tt.dialect, tt.in)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantQuote, got.Quote(tt.dialect))
+		})
+	}
+}
+
+func TestParseQualifiedColumn(t *testing.T) {
+	got, err := name.ParseQualifiedColumn(name.DialectPostgres, `"public"."User"."user_id"`)
+	require.NoError(t, err)
+	assert.Equal(t, name.Qualified{Schema: "public", Object: "User", Column: "user_id"}, got)
+	assert.Equal(t, "UserId", got.ColumnName().UpperCamel())
+	assert.Equal(t, "user", got.ObjectName().LowerSnake())
+	assert.Equal(t, "public.User.user_id", got.String())
+
+	got, err = name.ParseQualifiedColumn(name.DialectSpanner, "`Col`")
+	require.NoError(t, err)
+	assert.Equal(t, name.Qualified{Column: "Col"}, got)
+}
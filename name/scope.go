@@ -0,0 +1,113 @@
+package name
+
+import (
+	"fmt"
+)
+
+// GoKeywords is the list of the keywords of Go, which cannot be used as identifiers.
+var GoKeywords = []string{
+	"break", "case", "chan", "const", "continue", "default", "defer", "else", "fallthrough", "for",
+	"func", "go", "goto", "if", "import", "interface", "map", "package", "range", "return",
+	"select", "struct", "switch", "type", "var",
+}
+
+// Scope hands out unique identifiers within a naming scope such as a package, a type or a method.
+// An identifier declared in a scope does not collide with identifiers declared or reserved in the scope and its ancestors.
+type Scope struct {
+	parent     *Scope
+	idents     map[string]string
+	keys       map[string]string
+	collisions []Collision
+}
+
+// Collision describes that an identifier requested for a key was already taken and has been disambiguated.
+type Collision struct {
+	// Key is the key for which the identifier was requested.
+	Key string
+	// Identifier is the requested identifier.
+	Identifier string
+	// TakenBy is the key that had already taken the identifier, which is empty if the identifier is reserved.
+	TakenBy string
+	// Resolved is the identifier handed out instead of the requested one.
+	Resolved string
+}
+
+func (c Collision) String() string {
+	if c.TakenBy == "" {
+		return fmt.Sprintf(`%q for %q is reserved and resolved as %q`, c.Identifier, c.Key, c.Resolved)
+	}
+	return fmt.Sprintf(`%q for %q collides with %q and resolved as %q`, c.Identifier, c.Key, c.TakenBy, c.Resolved)
+}
+
+// NewScope returns a new root scope.
+func NewScope() *Scope {
+	return &Scope{idents: map[string]string{}, keys: map[string]string{}}
+}
+
+// Child returns a new scope nested in the scope.
+func (s *Scope) Child() *Scope {
+	c := NewScope()
+	c.parent = s
+	return c
+}
+
+// Reserve reserves the identifiers so that they are never handed out in the scope and its descendants.
+func (s *Scope) Reserve(idents ...string) {
+	for _, ident := range idents {
+		if _, ok := s.idents[ident]; !ok {
+			s.idents[ident] = ""
+		}
+	}
+}
+
+// Declare returns a unique identifier for the key in the scope.
+// The requested identifier is returned if it is not taken, otherwise it is disambiguated by appending the smallest number starting from 2 that makes it unique.
+// The number is separated by "_" if the identifier ends with a digit.
+// Declaring the same key again returns the same identifier.
+// The result is deterministic as long as keys are declared in the same order.
+func (s *Scope) Declare(key, ident string) string {
+	if resolved, ok := s.keys[key]; ok {
+		return resolved
+	}
+	resolved := ident
+	if takenBy, taken := s.lookupIdent(ident); taken {
+		base := ident
+		if rs := []rune(ident); len(rs) > 0 && isDigitRune(rs[len(rs)-1]) {
+			base += "_"
+		}
+		for i := 2; ; i++ {
+			resolved = fmt.Sprintf(`%s%d`, base, i)
+			if _, taken := s.lookupIdent(resolved); !taken {
+				break
+			}
+		}
+		s.collisions = append(s.collisions, Collision{Key: key, Identifier: ident, TakenBy: takenBy, Resolved: resolved})
+	}
+	s.idents[resolved] = key
+	s.keys[key] = resolved
+	return resolved
+}
+
+// Lookup returns the identifier declared for the key in the scope or its ancestors.
+func (s *Scope) Lookup(key string) (ident string, found bool) {
+	for c := s; c != nil; c = c.parent {
+		if ident, ok := c.keys[key]; ok {
+			return ident, true
+		}
+	}
+	return "", false
+}
+
+// Collisions returns the collisions that occurred on declarations in the scope, in the order of declarations.
+func (s *Scope) Collisions() []Collision {
+	return append([]Collision{}, s.collisions...)
+}
+
+func (s *Scope) lookupIdent(ident string) (takenBy string, taken bool) {
+	for c := s; c != nil; c = c.parent {
+		if key, ok := c.idents[ident]; ok {
+			return key, true
+		}
+	}
+	return "", false
+}
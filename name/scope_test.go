@@ -0,0 +1,94 @@
+package name_test
+
+import (
+	"github.com/Jumpaku/schenerate/name"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestScope_Declare(t *testing.T) {
+	type decl struct {
+		key   string
+		ident string
+	}
+	tests := []struct {
+		name     string
+		reserved []string
+		in       []decl
+		want     []string
+	}{
+		{
+			name: "unique",
+			in:   []decl{{"user_id", "UserId"}, {"name", "Name"}},
+			want: []string{"UserId", "Name"},
+		},
+		{
+			name: "collision",
+			in: []decl{
+				{"user_id", name.New("user_id").UpperCamel()},
+				{"userId", name.New("userId").UpperCamel()},
+				{"UserID", name.New("user id").UpperCamel()},
+			},
+			want: []string{"UserId", "UserId2", "UserId3"},
+		},
+		{
+			name: "collision with generated",
+			in:   []decl{{"a", "Col"}, {"b", "Col2"}, {"c", "Col"}},
+			want: []string{"Col", "Col2", "Col3"},
+		},
+		{
+			name: "same key",
+			in:   []decl{{"Col_1", "Col1"}, {"Col_1", "Col1"}, {"col1", "Col1"}},
+			want: []string{"Col1", "Col1", "Col1_2"},
+		},
+		{
+			name:     "reserved",
+			reserved: name.GoKeywords,
+			in:       []decl{{"type", "type"}, {"range", "range"}, {"value", "value"}},
+			want:     []string{"type2", "range2", "value"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sut := name.NewScope()
+			sut.Reserve(tt.reserved...)
+			var got []string
+			for _, d := range tt.in {
+				got = append(got, sut.Declare(d.key, d.ident))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestScope_Child(t *testing.T) {
+	pkg := name.NewScope()
+	typ := pkg.Child()
+	method := typ.Child()
+	other := pkg.Child()
+
+	assert.Equal(t, "User", pkg.Declare("users", "User"))
+	assert.Equal(t, "ID", typ.Declare("id", "ID"))
+	assert.Equal(t, "User2", typ.Declare("user", "User"))
+	assert.Equal(t, "ID2", method.Declare("local id", "ID"))
+	assert.Equal(t, "ID", other.Declare("id", "ID"))
+
+	got, found := method.Lookup("users")
+	assert.True(t, found)
+	assert.Equal(t, "User", got)
+	_, found = pkg.Lookup("id")
+	assert.False(t, found)
+}
+
+func TestScope_Collisions(t *testing.T) {
+	sut := name.NewScope()
+	sut.Reserve("type")
+	sut.Declare("Col_1", "Col1")
+	sut.Declare("col1", "Col1")
+	sut.Declare("type", "type")
+
+	assert.Equal(t, []name.Collision{
+		{Key: "col1", Identifier: "Col1", TakenBy: "Col_1", Resolved: "Col1_2"},
+		{Key: "type", Identifier: "type", TakenBy: "", Resolved: "type2"},
+	}, sut.Collisions())
+}
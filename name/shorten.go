@@ -0,0 +1,77 @@
+package name
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Maximum lengths of identifiers in bytes.
+const (
+	MaxLenPostgres = 63
+	MaxLenSpanner  = 128
+)
+
+const shortenHashLen = 8
+
+// Shorten returns s as is if its length in bytes does not exceed maxLen.
+// Otherwise, it abbreviates the words of s by removing non-leading vowels, starting from the longest word, until the result fits.
+// If the abbreviated words still do not fit, they are truncated.
+// Finally, "_" and a hash of s are appended so that the result is unique and reproducible.
+func Shorten(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+
+	sum := sha256.Sum256([]byte(s))
+	hash := hex.EncodeToString(sum[:])[:shortenHashLen]
+	budget := maxLen - len("_") - len(hash)
+	if budget <= 0 {
+		return hash[:max(maxLen, 0)]
+	}
+
+	words := New(s).words
+	abbreviated := make([]bool, len(words))
+	for length(words) > budget {
+		longest := -1
+		for i, w := range words {
+			if !abbreviated[i] && (longest < 0 || len(w) > len(words[longest])) {
+				longest = i
+			}
+		}
+		if longest < 0 {
+			break
+		}
+		words[longest] = abbreviate(words[longest])
+		abbreviated[longest] = true
+	}
+
+	prefix := strings.Join(words, "")
+	for len(prefix) > budget {
+		rs := []rune(prefix)
+		prefix = string(rs[:len(rs)-1])
+	}
+	return strings.TrimRight(prefix, "_") + "_" + hash
+}
+
+func length(words []string) int {
+	n := 0
+	for _, w := range words {
+		n += len(w)
+	}
+	return n
+}
+
+func abbreviate(w string) string {
+	rs := []rune(w)
+	if len(rs) == 0 {
+		return w
+	}
+	abbr := []rune{rs[0]}
+	for _, r := range rs[1:] {
+		if !strings.ContainsRune("aeiouAEIOU", r) {
+			abbr = append(abbr, r)
+		}
+	}
+	return string(abbr)
+}
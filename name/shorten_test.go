@@ -0,0 +1,45 @@
+package name_test
+
+import (
+	"github.com/Jumpaku/schenerate/name"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestShorten(t *testing.T) {
+	tests := []struct {
+		in     string
+		maxLen int
+		want   string
+	}{
+		{in: "fk_orders_customers", maxLen: name.MaxLenPostgres, want: "fk_orders_customers"},
+		{in: "fk_orders_customers", maxLen: 19, want: "fk_orders_customers"},
+		{
+			in:     "fk_order_items_customer_id_customers_customer_identifier_and_more_words",
+			maxLen: name.MaxLenPostgres,
+			want:   "fk_ordr_itms_cstmr_id_cstmrs_cstmr_idntfr_and_mr_wrds_91ad9154",
+		},
+		{
+			in:     "fk_order_items_customer_id_customers_customer_identifier_and_more_words",
+			maxLen: 30,
+			want:   "fk_ordr_itms_cstmr_id_91ad9154",
+		},
+		{in: "uq_users_email_address", maxLen: 20, want: "uq_usrs_eml_46d05f6e"},
+		{in: "idx_a_b", maxLen: 5, want: "d904e"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got := name.Shorten(tt.in, tt.maxLen)
+			assert.Equal(t, tt.want, got)
+			assert.LessOrEqual(t, len(got), tt.maxLen)
+		})
+	}
+}
+
+func TestShorten_Unique(t *testing.T) {
+	a := "idx_" + strings.Repeat("column_", 20) + "a"
+	b := "idx_" + strings.Repeat("column_", 20) + "b"
+	assert.NotEqual(t, name.Shorten(a, name.MaxLenPostgres), name.Shorten(b, name.MaxLenPostgres))
+	assert.Equal(t, name.Shorten(a, name.MaxLenPostgres), name.Shorten(a, name.MaxLenPostgres))
+}
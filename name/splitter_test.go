@@ -0,0 +1,47 @@
+package name_test
+
+import (
+	"github.com/Jumpaku/schenerate/name"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSplitter_New(t *testing.T) {
+	tests := []struct {
+		name      string
+		sut       name.Splitter
+		in        string
+		wantSnake string
+		wantCamel string
+	}{
+		{name: "default", sut: name.Splitter{}, in: "Col01", wantSnake: "col01", wantCamel: "Col01"},
+		{name: "default with separator", sut: name.Splitter{}, in: "Col_01", wantSnake: "col_01", wantCamel: "Col01"},
+		{name: "split digits", sut: name.Splitter{SplitDigits: true}, in: "Col01", wantSnake: "col_01", wantCamel: "Col01"},
+		{name: "split digits before letters", sut: name.Splitter{SplitDigits: true}, in: "v2api", wantSnake: "v_2_api", wantCamel: "V2Api"},
+		{name: "custom separators", sut: name.Splitter{Separators: []rune{'_'}}, in: "user.name_id", wantSnake: "user.name_id", wantCamel: "User.nameId"},
+		{name: "custom separators with non-ascii", sut: name.Splitter{Separators: []rune{'_'}}, in: "ユーザー_id", wantSnake: "ユーザー_id", wantCamel: "ユーザーId"},
+		{name: "unsplittable", sut: name.Splitter{Unsplittable: []string{"OAuth2"}}, in: "OAuth2Token", wantSnake: "oauth2_token", wantCamel: "Oauth2Token"},
+		{name: "unsplittable with split digits", sut: name.Splitter{SplitDigits: true, Unsplittable: []string{"OAuth2"}}, in: "user_OAuth2", wantSnake: "user_oauth2", wantCamel: "UserOauth2"},
+		{name: "preserve case", sut: name.Splitter{PreserveCase: []string{"iOS"}}, in: "iOSDevice", wantSnake: "ios_device", wantCamel: "iOSDevice"},
+		{name: "unsplittable not at boundary", sut: name.Splitter{Unsplittable: []string{"iOS"}}, in: "radiOS", wantSnake: "radi_os", wantCamel: "RadiOs"},
+		{name: "unsplittable at case transition", sut: name.Splitter{Unsplittable: []string{"OAuth2"}}, in: "userOAuth2", wantSnake: "user_oauth2", wantCamel: "UserOauth2"},
+		{name: "unsplittable not at end boundary", sut: name.Splitter{Unsplittable: []string{"iOS"}}, in: "iOSystem", wantSnake: "i_osystem", wantCamel: "IOsystem"},
+		{name: "preserve case not at end boundary", sut: name.Splitter{PreserveCase: []string{"ID"}}, in: "IDentity", wantSnake: "identity", wantCamel: "Identity"},
+		{name: "preserve case in middle", sut: name.Splitter{PreserveCase: []string{"OAuth2"}}, in: "user_OAuth2_token", wantSnake: "user_oauth2_token", wantCamel: "UserOAuth2Token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := tt.sut.New(tt.in)
+			assert.Equal(t, tt.wantSnake, n.LowerSnake())
+			assert.Equal(t, tt.wantCamel, n.UpperCamel())
+		})
+	}
+}
+
+func TestSplitter_New_LowerCamel(t *testing.T) {
+	sut := name.Splitter{PreserveCase: []string{"iOS", "OAuth2"}}
+	assert.Equal(t, "iOSDevice", sut.New("iOS_device").LowerCamel())
+	assert.Equal(t, "OAuth2Token", sut.New("OAuth2Token").LowerCamel())
+	assert.Equal(t, "userIos", sut.New("user_IOS").LowerCamel())
+	assert.Equal(t, "deviceiOS", sut.New("device").Append("iOS").LowerCamel())
+}
@@ -0,0 +1,238 @@
+// Package plugin defines the protocol of out-of-process generators, which can be written in any language.
+//
+// A plugin is an executable that reads a Request in JSON from stdin and writes a Response in JSON to stdout,
+// similarly to the plugins of protoc. The files in the response are fed into files.Writer by the host.
+// Plugins written in Go can use Serve to handle the protocol.
+// Plugins compiled to WASI WebAssembly are run in-process by WASM with the same protocol.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Jumpaku/schenerate/files"
+	"github.com/Jumpaku/schenerate/schema"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ProtocolVersion is the version of the protocol, which is incremented on incompatible changes.
+const ProtocolVersion = 1
+
+// Request is sent to a plugin, which has either Schemas or Rows.
+type Request struct {
+	Version int    `json:"version"`
+	Dialect string `json:"dialect"`
+	// Parameters is the parameters of the plugin given by the user.
+	Parameters map[string]string `json:"parameters"`
+	Schemas    schema.Schemas    `json:"schemas,omitempty"`
+	Rows       []schema.Row      `json:"rows,omitempty"`
+}
+
+// Response is returned by a plugin.
+type Response struct {
+	Version int    `json:"version"`
+	Files   []File `json:"files"`
+	// Diagnostics is the messages reported by the plugin, where any diagnostic of SeverityError fails the generation.
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// AddFile adds a file with the content.
+func (r *Response) AddFile(path, content string) {
+	r.Files = append(r.Files, File{Path: path, Content: content})
+}
+
+// Report adds a diagnostic of the severity.
+func (r *Response) Report(severity Severity, file, format string, args ...any) {
+	r.Diagnostics = append(r.Diagnostics, Diagnostic{Severity: severity, File: file, Message: fmt.Sprintf(format, args...)})
+}
+
+// File is a generated file, whose path is a slash-separated path relative to the output directory.
+type File struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+// Severity is the severity of a diagnostic.
+type Severity string
+
+const (
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// Diagnostic is a message reported by a plugin, optionally about a file.
+type Diagnostic struct {
+	Severity Severity `json:"severity"`
+	File     string   `json:"file,omitempty"`
+	Message  string   `json:"message"`
+}
+
+func (d Diagnostic) String() string {
+	if d.File == "" {
+		return fmt.Sprintf(`%s: %s`, d.Severity, d.Message)
+	}
+	return fmt.Sprintf(`%s: %s: %s`, d.Severity, d.File, d.Message)
+}
+
+// Plugin is an executable implementing the protocol.
+type Plugin struct {
+	Command string
+	Args    []string
+	// Dir is the working directory of the plugin, which defaults to the current directory.
+	Dir string
+	// Parameters is sent to the plugin in the requests.
+	Parameters map[string]string
+	// Stderr receives the stderr of the plugin and the diagnostics other than errors, which are discarded if it is nil.
+	Stderr io.Writer
+}
+
+// Call sends the request to the plugin and returns the response.
+// The version of the request is set to ProtocolVersion.
+func (p Plugin) Call(ctx context.Context, req Request) (*Response, error) {
+	return call(p.Command, req, func(stdin io.Reader, stdout io.Writer) error {
+		cmd := exec.CommandContext(ctx, p.Command, p.Args...)
+		cmd.Dir = p.Dir
+		cmd.Stdin = stdin
+		cmd.Stdout = stdout
+		cmd.Stderr = p.Stderr
+		return cmd.Run()
+	})
+}
+
+// Generate sends the request to the plugin and adds the files in the response to out under outDir.
+// It fails if the plugin reports any error or returns a file whose path is not local.
+func (p Plugin) Generate(ctx context.Context, out *files.Writer, outDir string, req Request) error {
+	return generate(ctx, p, out, outDir, req)
+}
+
+// GeneratorWithSchema returns a generator sending the schemas of the dialect to the plugin, which can be passed to schema.GenerateWithSchema.
+func (p Plugin) GeneratorWithSchema(ctx context.Context, dialect, outDir string) schema.GeneratorWithSchema {
+	return generatorWithSchema(ctx, p, dialect, outDir)
+}
+
+// GeneratorWithQuery returns a generator sending the rows of the dialect to the plugin, which can be passed to schema.GenerateWithQuery.
+func (p Plugin) GeneratorWithQuery(ctx context.Context, dialect, outDir string) schema.GeneratorWithQuery {
+	return generatorWithQuery(ctx, p, dialect, outDir)
+}
+
+func (p Plugin) info() (name string, parameters map[string]string, stderr io.Writer) {
+	return p.Command, p.Parameters, p.Stderr
+}
+
+// caller is a plugin implementing the protocol, which is either Plugin or WASM.
+type caller interface {
+	Call(ctx context.Context, req Request) (*Response, error)
+	// info returns the name of the plugin for messages, the parameters sent to it and the writer of its diagnostics.
+	info() (name string, parameters map[string]string, stderr io.Writer)
+}
+
+func generate(ctx context.Context, c caller, out *files.Writer, outDir string, req Request) error {
+	res, err := c.Call(ctx, req)
+	if err != nil {
+		return err
+	}
+	name, _, stderr := c.info()
+	return write(name, stderr, out, outDir, res)
+}
+
+func generatorWithSchema(ctx context.Context, c caller, dialect, outDir string) schema.GeneratorWithSchema {
+	_, parameters, _ := c.info()
+	return func(out *files.Writer, schemas schema.Schemas) error {
+		return generate(ctx, c, out, outDir, Request{Dialect: dialect, Parameters: parameters, Schemas: schemas})
+	}
+}
+
+func generatorWithQuery(ctx context.Context, c caller, dialect, outDir string) schema.GeneratorWithQuery {
+	_, parameters, _ := c.info()
+	return func(out *files.Writer, rows []schema.Row) error {
+		return generate(ctx, c, out, outDir, Request{Dialect: dialect, Parameters: parameters, Rows: rows})
+	}
+}
+
+func call(name string, req Request, run func(stdin io.Reader, stdout io.Writer) error) (*Response, error) {
+	req.Version = ProtocolVersion
+	in, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to marshal request: %w`, err)
+	}
+
+	var stdout bytes.Buffer
+	if err := run(bytes.NewReader(in), &stdout); err != nil {
+		return nil, fmt.Errorf(`fail to run plugin %s: %w`, name, err)
+	}
+
+	var res Response
+	if err := json.Unmarshal(stdout.Bytes(), &res); err != nil {
+		return nil, fmt.Errorf(`fail to unmarshal response of plugin %s: %w`, name, err)
+	}
+	if res.Version != ProtocolVersion {
+		return nil, fmt.Errorf(`unsupported protocol version %d of plugin %s: %d expected`, res.Version, name, ProtocolVersion)
+	}
+	return &res, nil
+}
+
+func write(name string, stderr io.Writer, out *files.Writer, outDir string, res *Response) error {
+	var errs []error
+	for _, d := range res.Diagnostics {
+		if d.Severity == SeverityError {
+			errs = append(errs, errors.New(d.String()))
+		} else if stderr != nil {
+			fmt.Fprintf(stderr, "%s: %s\n", name, d)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf(`plugin %s reported errors: %w`, name, errors.Join(errs...))
+	}
+
+	for _, f := range res.Files {
+		path := filepath.FromSlash(f.Path)
+		if !filepath.IsLocal(path) {
+			return fmt.Errorf(`plugin %s returned file %q outside of the output directory`, name, f.Path)
+		}
+		out.Add(filepath.Join(outDir, path))
+		if _, err := io.WriteString(out, f.Content); err != nil {
+			return fmt.Errorf(`fail to write file %s: %w`, f.Path, err)
+		}
+	}
+	return nil
+}
+
+// Handler handles a request by adding files and diagnostics to the response.
+// A returned error is reported to the host as a diagnostic of SeverityError.
+type Handler func(req *Request, res *Response) error
+
+// Serve handles a request from stdin and writes the response to stdout, which is called in the main function of a plugin.
+// It exits with status 1 if the protocol fails.
+func Serve(h Handler) {
+	if err := ServeIO(os.Stdin, os.Stdout, h); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// ServeIO handles a request from r and writes the response to w.
+func ServeIO(r io.Reader, w io.Writer, h Handler) error {
+	var req Request
+	if err := json.NewDecoder(r).Decode(&req); err != nil {
+		return fmt.Errorf(`fail to unmarshal request: %w`, err)
+	}
+
+	res := Response{Version: ProtocolVersion, Files: []File{}, Diagnostics: []Diagnostic{}}
+	if req.Version != ProtocolVersion {
+		res.Report(SeverityError, "", `unsupported protocol version %d: %d expected`, req.Version, ProtocolVersion)
+	} else if err := h(&req, &res); err != nil {
+		res.Report(SeverityError, "", `%s`, strings.TrimSpace(err.Error()))
+	}
+
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		return fmt.Errorf(`fail to marshal response: %w`, err)
+	}
+	return nil
+}
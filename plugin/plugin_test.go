@@ -0,0 +1,163 @@
+package plugin_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/Jumpaku/schenerate/files"
+	"github.com/Jumpaku/schenerate/plugin"
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const pluginEnv = "SCHENERATE_PLUGIN_TEST"
+
+// TestMain runs the test binary as a plugin if pluginEnv is set.
+func TestMain(m *testing.M) {
+	if os.Getenv(pluginEnv) != "" {
+		plugin.Serve(handle)
+		return
+	}
+	os.Exit(m.Run())
+}
+
+func handle(req *plugin.Request, res *plugin.Response) error {
+	switch req.Parameters["mode"] {
+	case "error":
+		return fmt.Errorf(`something wrong`)
+	case "escape":
+		res.AddFile("../escaped.txt", "")
+		return nil
+	}
+	for _, s := range req.Schemas {
+		var columns []string
+		for _, c := range s.Columns {
+			columns = append(columns, c.Name)
+		}
+		res.AddFile("tables/"+s.Name+".txt", strings.Join(columns, ","))
+	}
+	for i, r := range req.Rows {
+		res.AddFile(fmt.Sprintf("rows/%d.txt", i), fmt.Sprint(r["id"]))
+	}
+	res.Report(plugin.SeverityWarning, "", "dialect=%s", req.Dialect)
+	return nil
+}
+
+func testPlugin(t *testing.T, parameters map[string]string) plugin.Plugin {
+	t.Helper()
+	t.Setenv(pluginEnv, "1")
+	return plugin.Plugin{Command: os.Args[0], Parameters: parameters}
+}
+
+func TestPlugin_GeneratorWithSchema(t *testing.T) {
+	schemas := schema.Schemas{
+		{Dialect: schema.DialectSQLite3, Name: "users", Columns: []schema.Column{{Name: "id"}, {Name: "name"}}},
+		{Dialect: schema.DialectSQLite3, Name: "items", Columns: []schema.Column{{Name: "id"}}},
+	}
+	testcases := []struct {
+		name       string
+		parameters map[string]string
+		want       map[string]string
+		wantErr    bool
+	}{
+		{
+			name: "files",
+			want: map[string]string{
+				"tables/users.txt": "id,name",
+				"tables/items.txt": "id",
+			},
+		},
+		{name: "reported error", parameters: map[string]string{"mode": "error"}, wantErr: true},
+		{name: "file outside of output directory", parameters: map[string]string{"mode": "escape"}, wantErr: true},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			outDir := t.TempDir()
+			var stderr bytes.Buffer
+			p := testPlugin(t, testcase.parameters)
+			p.Stderr = &stderr
+
+			w := &files.Writer{}
+			err := p.GeneratorWithSchema(context.Background(), "sqlite3", outDir)(w, schemas)
+			if testcase.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Nil(t, w.SaveAll())
+			for name, want := range testcase.want {
+				got, err := os.ReadFile(filepath.Join(outDir, filepath.FromSlash(name)))
+				require.Nil(t, err)
+				require.Equal(t, want, string(got))
+			}
+			require.Contains(t, stderr.String(), "warning: dialect=sqlite3")
+		})
+	}
+}
+
+func TestPlugin_GeneratorWithQuery(t *testing.T) {
+	outDir := t.TempDir()
+	p := testPlugin(t, nil)
+
+	w := &files.Writer{}
+	err := p.GeneratorWithQuery(context.Background(), "sqlite3", outDir)(w, []schema.Row{{"id": 1}, {"id": 2}})
+	require.Nil(t, err)
+	require.Equal(t, []string{filepath.Join(outDir, "rows", "0.txt"), filepath.Join(outDir, "rows", "1.txt")}, w.Paths())
+}
+
+func TestServeIO(t *testing.T) {
+	testcases := []struct {
+		name    string
+		in      string
+		want    plugin.Response
+		wantErr bool
+	}{
+		{
+			name: "files",
+			in:   `{"version":1,"dialect":"spanner","schemas":[{"name":"Users","columns":[{"name":"Id"}]}]}`,
+			want: plugin.Response{
+				Version:     plugin.ProtocolVersion,
+				Files:       []plugin.File{{Path: "tables/Users.txt", Content: "Id"}},
+				Diagnostics: []plugin.Diagnostic{{Severity: plugin.SeverityWarning, Message: "dialect=spanner"}},
+			},
+		},
+		{
+			name: "handler error",
+			in:   `{"version":1,"parameters":{"mode":"error"}}`,
+			want: plugin.Response{
+				Version:     plugin.ProtocolVersion,
+				Files:       []plugin.File{},
+				Diagnostics: []plugin.Diagnostic{{Severity: plugin.SeverityError, Message: "something wrong"}},
+			},
+		},
+		{
+			name: "unsupported version",
+			in:   `{"version":0}`,
+			want: plugin.Response{
+				Version:     plugin.ProtocolVersion,
+				Files:       []plugin.File{},
+				Diagnostics: []plugin.Diagnostic{{Severity: plugin.SeverityError, Message: "unsupported protocol version 0: 1 expected"}},
+			},
+		},
+		{name: "invalid request", in: `{`, wantErr: true},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := plugin.ServeIO(strings.NewReader(testcase.in), &out, handle)
+			if testcase.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			var got plugin.Response
+			require.Nil(t, json.Unmarshal(out.Bytes(), &got))
+			require.Equal(t, testcase.want, got)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+// Command wasm is a plugin for the tests of WASM, which is built by GOOS=wasip1 GOARCH=wasm.
+package main
+
+import (
+	"fmt"
+	"github.com/Jumpaku/schenerate/plugin"
+	"net"
+	"os"
+)
+
+func main() {
+	plugin.Serve(func(req *plugin.Request, res *plugin.Response) error {
+		switch req.Parameters["mode"] {
+		case "fs":
+			if _, err := os.ReadDir("/"); err != nil {
+				return fmt.Errorf(`fail to read directory: %w`, err)
+			}
+		case "network":
+			if _, err := net.Dial("tcp", "example.com:80"); err != nil {
+				return fmt.Errorf(`fail to dial: %w`, err)
+			}
+		case "exit":
+			os.Exit(3)
+		case "loop":
+			for {
+			}
+		case "memory":
+			b := make([]byte, 1<<30)
+			res.AddFile("memory.txt", string(b[:1]))
+		}
+		for _, s := range req.Schemas {
+			res.AddFile(s.Name+".txt", req.Dialect+"."+s.Name)
+		}
+		return nil
+	})
+}
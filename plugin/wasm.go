@@ -0,0 +1,127 @@
+package plugin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/Jumpaku/schenerate/files"
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultMemoryLimitPages is the default memory limit of WASM plugins in 64 KiB pages, which is 256 MiB.
+	DefaultMemoryLimitPages = 4096
+	// DefaultTimeout is the default timeout of a call of WASM plugins.
+	DefaultTimeout = time.Minute
+)
+
+// compilationCache caches the compiled modules across the calls, e.g. of repeated generations in watch mode.
+var compilationCache = wazero.NewCompilationCache()
+
+// WASM is a plugin compiled to WASI WebAssembly, e.g. by GOOS=wasip1 GOARCH=wasm, which implements the protocol by stdin and stdout.
+// It is run in-process by a pure-Go runtime without access to the filesystem, the network, the environment variables and the clock.
+type WASM struct {
+	Path string
+	// SHA256 is the hex-encoded SHA-256 hash of the module file, which is verified before running if it is not empty.
+	SHA256 string
+	// Parameters is sent to the plugin in the requests.
+	Parameters map[string]string
+	// Stderr receives the stderr of the plugin and the diagnostics other than errors, which are discarded if it is nil.
+	Stderr io.Writer
+	// MemoryLimitPages is the maximum memory of the module in 64 KiB pages, which defaults to DefaultMemoryLimitPages if 0.
+	MemoryLimitPages uint32
+	// Timeout is the maximum duration of a call, which defaults to DefaultTimeout if 0.
+	Timeout time.Duration
+}
+
+// Hash returns the hex-encoded SHA-256 hash of the module, which can be used to pin the module by WASM.SHA256.
+func Hash(module []byte) string {
+	h := sha256.Sum256(module)
+	return hex.EncodeToString(h[:])
+}
+
+// Call runs the module with the request and returns the response.
+// The version of the request is set to ProtocolVersion.
+// The module is aborted if it exceeds the timeout or ctx is done, and fails to grow its memory beyond the memory limit.
+func (p WASM) Call(ctx context.Context, req Request) (*Response, error) {
+	module, err := os.ReadFile(p.Path)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to read plugin %s: %w`, p.Path, err)
+	}
+	if p.SHA256 != "" {
+		if got := Hash(module); !strings.EqualFold(got, p.SHA256) {
+			return nil, fmt.Errorf(`hash of plugin %s mismatched: got %s, want %s`, p.Path, got, p.SHA256)
+		}
+	}
+
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	memoryLimitPages := p.MemoryLimitPages
+	if memoryLimitPages == 0 {
+		memoryLimitPages = DefaultMemoryLimitPages
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return call(p.Path, req, func(stdin io.Reader, stdout io.Writer) error {
+		config := wazero.NewRuntimeConfig().
+			WithCloseOnContextDone(true).
+			WithMemoryLimitPages(memoryLimitPages).
+			WithCompilationCache(compilationCache)
+		r := wazero.NewRuntimeWithConfig(ctx, config)
+		defer r.Close(ctx)
+
+		wasi_snapshot_preview1.MustInstantiate(ctx, r)
+		compiled, err := r.CompileModule(ctx, module)
+		if err != nil {
+			return fmt.Errorf(`fail to compile module: %w`, err)
+		}
+		stderr := p.Stderr
+		if stderr == nil {
+			stderr = io.Discard
+		}
+		moduleConfig := wazero.NewModuleConfig().
+			WithName("").
+			WithArgs(filepath.Base(p.Path)).
+			WithStdin(stdin).
+			WithStdout(stdout).
+			WithStderr(stderr)
+		if _, err := r.InstantiateModule(ctx, compiled, moduleConfig); err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf(`%w: %w`, ctx.Err(), err)
+			}
+			return err
+		}
+		return nil
+	})
+}
+
+// Generate runs the module with the request and adds the files in the response to out under outDir.
+// It fails if the plugin reports any error or returns a file whose path is not local.
+func (p WASM) Generate(ctx context.Context, out *files.Writer, outDir string, req Request) error {
+	return generate(ctx, p, out, outDir, req)
+}
+
+// GeneratorWithSchema returns a generator sending the schemas of the dialect to the plugin, which can be passed to schema.GenerateWithSchema.
+func (p WASM) GeneratorWithSchema(ctx context.Context, dialect, outDir string) schema.GeneratorWithSchema {
+	return generatorWithSchema(ctx, p, dialect, outDir)
+}
+
+// GeneratorWithQuery returns a generator sending the rows of the dialect to the plugin, which can be passed to schema.GenerateWithQuery.
+func (p WASM) GeneratorWithQuery(ctx context.Context, dialect, outDir string) schema.GeneratorWithQuery {
+	return generatorWithQuery(ctx, p, dialect, outDir)
+}
+
+func (p WASM) info() (name string, parameters map[string]string, stderr io.Writer) {
+	return p.Path, p.Parameters, p.Stderr
+}
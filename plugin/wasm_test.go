@@ -0,0 +1,72 @@
+package plugin_test
+
+import (
+	"context"
+	"github.com/Jumpaku/schenerate/files"
+	"github.com/Jumpaku/schenerate/plugin"
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/stretchr/testify/require"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func buildWASM(t *testing.T) (path string, hash string) {
+	t.Helper()
+	path = filepath.Join(t.TempDir(), "plugin.wasm")
+	cmd := exec.Command("go", "build", "-o", path, "./testdata/wasm")
+	cmd.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Skipf("fail to build WASM plugin: %v: %s", err, out)
+	}
+	b, err := os.ReadFile(path)
+	require.Nil(t, err)
+	return path, plugin.Hash(b)
+}
+
+func TestWASM_GeneratorWithSchema(t *testing.T) {
+	path, hash := buildWASM(t)
+	schemas := schema.Schemas{{Dialect: schema.DialectSpanner, Name: "Users"}, {Dialect: schema.DialectSpanner, Name: "Items"}}
+	testcases := []struct {
+		name       string
+		sha256     string
+		parameters map[string]string
+		timeout    time.Duration
+		want       map[string]string
+		wantErr    bool
+	}{
+		{
+			name:   "files",
+			sha256: hash,
+			want:   map[string]string{"Users.txt": "spanner.Users", "Items.txt": "spanner.Items"},
+		},
+		{name: "hash mismatched", sha256: plugin.Hash(nil), wantErr: true},
+		{name: "filesystem", parameters: map[string]string{"mode": "fs"}, wantErr: true},
+		{name: "network", parameters: map[string]string{"mode": "network"}, wantErr: true},
+		{name: "exit", parameters: map[string]string{"mode": "exit"}, wantErr: true},
+		{name: "timeout", parameters: map[string]string{"mode": "loop"}, timeout: 100 * time.Millisecond, wantErr: true},
+		{name: "memory limit", parameters: map[string]string{"mode": "memory"}, wantErr: true},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			outDir := t.TempDir()
+			p := plugin.WASM{Path: path, SHA256: testcase.sha256, Parameters: testcase.parameters, Timeout: testcase.timeout}
+
+			w := &files.Writer{}
+			err := p.GeneratorWithSchema(context.Background(), "spanner", outDir)(w, schemas)
+			if testcase.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Nil(t, w.SaveAll())
+			for name, want := range testcase.want {
+				got, err := os.ReadFile(filepath.Join(outDir, name))
+				require.Nil(t, err)
+				require.Equal(t, want, string(got))
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ColumnType is a parsed representation of a column type such as character varying(50), numeric(10,2) and integer[].
+type ColumnType struct {
+	// Base is the canonical type name without parameters in lower case, e.g. character varying, numeric and timestamp with time zone.
+	Base string
+	// Length is the length of character and bit types, which is 0 if not specified.
+	Length int64
+	// Max is true if the length is unbounded, i.e. the type is text or bytea,
+	// or character varying or bit varying without the length.
+	Max bool
+	// Precision is the precision of numeric, time, timestamp and interval types, which is 0 if not specified.
+	Precision int64
+	// Scale is the scale of numeric types, which is 0 if not specified.
+	Scale int64
+	// Array is true if the type is an array, whose element type is Elem.
+	// Elem is nil if the element type is unknown, e.g. the type is given as ARRAY by information_schema.
+	Array bool
+	Elem  *ColumnType
+}
+
+// ParseType parses the type of the column.
+func (c Column) ParseType() (ColumnType, error) {
+	return ParseColumnType(c.Type)
+}
+
+// ParseColumnType parses a column type of PostgreSQL in the forms of format_type, which is introspected by ListSchemas,
+// and of information_schema.columns.data_type.
+func ParseColumnType(s string) (ColumnType, error) {
+	t, err := parseColumnType(strings.ToLower(strings.Join(strings.Fields(s), " ")))
+	if err != nil {
+		return ColumnType{}, fmt.Errorf(`fail to parse column type %q: %w`, s, err)
+	}
+	return t, nil
+}
+
+var columnTypeAliases = map[string]string{
+	"varchar":     "character varying",
+	"char":        "character",
+	"bpchar":      "character",
+	"varbit":      "bit varying",
+	"int":         "integer",
+	"int2":        "smallint",
+	"int4":        "integer",
+	"int8":        "bigint",
+	"float4":      "real",
+	"float8":      "double precision",
+	"bool":        "boolean",
+	"decimal":     "numeric",
+	"serial2":     "smallserial",
+	"serial4":     "serial",
+	"serial8":     "bigserial",
+	"timestamptz": "timestamp with time zone",
+	"timetz":      "time with time zone",
+	"timestamp":   "timestamp without time zone",
+	"time":        "time without time zone",
+}
+
+var lengthTypes = map[string]bool{
+	"character": true, "character varying": true, "bit": true, "bit varying": true,
+}
+
+var unboundedTypes = map[string]bool{
+	"text": true, "bytea": true, "character varying": true, "bit varying": true,
+}
+
+func parseColumnType(s string) (ColumnType, error) {
+	if s == "" {
+		return ColumnType{}, fmt.Errorf(`empty type`)
+	}
+	if s == "array" {
+		return ColumnType{Base: "array", Array: true}, nil
+	}
+	if strings.HasSuffix(s, "]") {
+		begin := strings.Index(s, "[")
+		if begin < 0 {
+			return ColumnType{}, fmt.Errorf(`unopened ']'`)
+		}
+		elem, err := parseColumnType(strings.TrimSpace(s[:begin]))
+		if err != nil {
+			return ColumnType{}, err
+		}
+		return ColumnType{Base: "array", Array: true, Elem: &elem}, nil
+	}
+	if strings.HasPrefix(s, "_") {
+		// Array types are prefixed with '_' in pg_type.
+		elem, err := parseColumnType(s[1:])
+		if err != nil {
+			return ColumnType{}, err
+		}
+		return ColumnType{Base: "array", Array: true, Elem: &elem}, nil
+	}
+
+	var params []int64
+	if begin := strings.Index(s, "("); begin >= 0 {
+		end := strings.Index(s, ")")
+		if end < begin {
+			return ColumnType{}, fmt.Errorf(`unclosed '('`)
+		}
+		for _, p := range strings.Split(s[begin+1:end], ",") {
+			v, err := strconv.ParseInt(strings.TrimSpace(p), 10, 64)
+			if err != nil {
+				return ColumnType{}, fmt.Errorf(`invalid parameter %q: %w`, p, err)
+			}
+			params = append(params, v)
+		}
+		// Parameters may precede the suffix, e.g. timestamp(3) with time zone.
+		s = strings.TrimSpace(strings.TrimSpace(s[:begin]) + " " + strings.TrimSpace(s[end+1:]))
+	}
+
+	t := ColumnType{Base: s}
+	if alias, ok := columnTypeAliases[s]; ok {
+		t.Base = alias
+	}
+	switch {
+	case len(params) == 0:
+		t.Max = unboundedTypes[t.Base]
+	case len(params) > 2:
+		return ColumnType{}, fmt.Errorf(`too many parameters for %s`, t.Base)
+	case lengthTypes[t.Base]:
+		if len(params) != 1 {
+			return ColumnType{}, fmt.Errorf(`too many parameters for %s`, t.Base)
+		}
+		t.Length = params[0]
+	default:
+		t.Precision = params[0]
+		if len(params) == 2 {
+			t.Scale = params[1]
+		}
+	}
+	return t, nil
+}
@@ -0,0 +1,72 @@
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/Jumpaku/schenerate/gotype"
+	"github.com/Jumpaku/schenerate/postgres"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestParseColumnType(t *testing.T) {
+	testcases := []struct {
+		in      string
+		want    postgres.ColumnType
+		wantErr bool
+	}{
+		{in: "integer", want: postgres.ColumnType{Base: "integer"}},
+		{in: "character varying", want: postgres.ColumnType{Base: "character varying", Max: true}},
+		{in: "text", want: postgres.ColumnType{Base: "text", Max: true}},
+		{in: "character", want: postgres.ColumnType{Base: "character"}},
+		{in: "character varying(50)", want: postgres.ColumnType{Base: "character varying", Length: 50}},
+		{in: "VARCHAR(50)", want: postgres.ColumnType{Base: "character varying", Length: 50}},
+		{in: "numeric(10,2)", want: postgres.ColumnType{Base: "numeric", Precision: 10, Scale: 2}},
+		{in: "timestamp(3) with time zone", want: postgres.ColumnType{Base: "timestamp with time zone", Precision: 3}},
+		{in: "timestamp", want: postgres.ColumnType{Base: "timestamp without time zone"}},
+		{in: "ARRAY", want: postgres.ColumnType{Base: "array", Array: true}},
+		{in: "integer[]", want: postgres.ColumnType{Base: "array", Array: true, Elem: &postgres.ColumnType{Base: "integer"}}},
+		{in: "_int4", want: postgres.ColumnType{Base: "array", Array: true, Elem: &postgres.ColumnType{Base: "integer"}}},
+		{in: "bit varying(8)[]", want: postgres.ColumnType{Base: "array", Array: true, Elem: &postgres.ColumnType{Base: "bit varying", Length: 8}}},
+		{in: "", wantErr: true},
+		{in: "numeric(10", wantErr: true},
+		{in: "numeric(a)", wantErr: true},
+		{in: "character(1,2)", wantErr: true},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.in, func(t *testing.T) {
+			got, err := postgres.ParseColumnType(testcase.in)
+			if testcase.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, testcase.want, got)
+		})
+	}
+}
+
+func TestColumn_ParseType_introspected(t *testing.T) {
+	database := fmt.Sprintf(`columntype_%d`, time.Now().Unix())
+	q, teardown := postgres.Setup(t, database, []string{`CREATE TABLE t (a varchar(50), b numeric(10,2), c text[])`})
+	defer teardown()
+
+	schemas, err := postgres.ListSchemas(context.Background(), q, []string{"t"})
+	require.Nil(t, err)
+	require.Len(t, schemas, 1)
+
+	want := map[string]postgres.ColumnType{
+		"a": {Base: "character varying", Length: 50},
+		"b": {Base: "numeric", Precision: 10, Scale: 2},
+		"c": {Base: "array", Array: true, Elem: &postgres.ColumnType{Base: "text", Max: true}},
+	}
+	mapper := postgres.NewGoTypeMapper(gotype.NullStylePointer)
+	for _, c := range schemas[0].Columns {
+		got, err := c.ParseType()
+		require.Nil(t, err)
+		require.Equal(t, want[c.Name], got, c.Name)
+		_, err = mapper.Map("t", c.Name, c.Type, c.Nullable)
+		require.Nil(t, err, c.Name)
+	}
+}
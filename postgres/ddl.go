@@ -0,0 +1,10 @@
+package postgres
+
+import (
+	"github.com/Jumpaku/schenerate/ddl"
+)
+
+// DDL returns the statements creating the tables of the schemas, which are rendered by ddl.Render.
+func (s Schemas) DDL() ([]string, error) {
+	return ddl.Render(s.Unified())
+}
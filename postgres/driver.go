@@ -0,0 +1,15 @@
+package postgres
+
+import (
+	"context"
+	"github.com/Jumpaku/schenerate/introspect"
+)
+
+// DriverName is the name of the driver registered to introspect, whose data source is a connection string of pgx.
+const DriverName = "postgres"
+
+func init() {
+	introspect.Register(DriverName, func(ctx context.Context, dataSource string) (introspect.Introspector, error) {
+		return Open(dataSource)
+	})
+}
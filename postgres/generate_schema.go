@@ -8,7 +8,7 @@ import (
 
 type Generator func(out *files.Writer, schemas Schemas) error
 
-func GenerateWithSchema(ctx context.Context, q queryer, tables []string, generator Generator) error {
+func GenerateWithSchema(ctx context.Context, q Queryer, tables []string, generator Generator) error {
 	schemas, err := ListSchemas(ctx, q, tables)
 	if err != nil {
 		return fmt.Errorf(`fail to list schemas: %w`, err)
@@ -24,3 +24,21 @@ func GenerateWithSchema(ctx context.Context, q queryer, tables []string, generat
 	}
 	return nil
 }
+
+// GenerateWithSnapshot calls generator with the schemas loaded from the snapshot file instead of querying a database.
+func GenerateWithSnapshot(path string, generator Generator) error {
+	schemas, err := LoadSnapshot(path)
+	if err != nil {
+		return fmt.Errorf(`fail to load snapshot: %w`, err)
+	}
+
+	w := &files.Writer{}
+	if err := generator(w, schemas); err != nil {
+		return err
+	}
+
+	if err := w.SaveAll(); err != nil {
+		return fmt.Errorf(`fail to save files writer: %w`, err)
+	}
+	return nil
+}
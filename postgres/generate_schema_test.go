@@ -32,18 +32,18 @@ func TestGenerateWithSchema(t *testing.T) {
 						{Name: "Col_01", Type: "bigint", Nullable: true},
 						{Name: "Col_02", Type: "bigint", Nullable: false},
 						{Name: "Col_04", Type: "bigint", Nullable: false},
-						{Name: "Col_05", Type: "bit", Nullable: true},
-						{Name: "Col_06", Type: "bit", Nullable: false},
-						{Name: "Col_07", Type: "bit varying", Nullable: true},
-						{Name: "Col_08", Type: "bit varying", Nullable: false},
+						{Name: "Col_05", Type: "bit(50)", Nullable: true},
+						{Name: "Col_06", Type: "bit(50)", Nullable: false},
+						{Name: "Col_07", Type: "bit varying(50)", Nullable: true},
+						{Name: "Col_08", Type: "bit varying(50)", Nullable: false},
 						{Name: "Col_09", Type: "boolean", Nullable: true},
 						{Name: "Col_10", Type: "boolean", Nullable: false},
 						{Name: "Col_11", Type: "bytea", Nullable: true},
 						{Name: "Col_12", Type: "bytea", Nullable: false},
-						{Name: "Col_13", Type: "character", Nullable: true},
-						{Name: "Col_14", Type: "character", Nullable: false},
-						{Name: "Col_15", Type: "character varying", Nullable: true},
-						{Name: "Col_16", Type: "character varying", Nullable: false},
+						{Name: "Col_13", Type: "character(50)", Nullable: true},
+						{Name: "Col_14", Type: "character(50)", Nullable: false},
+						{Name: "Col_15", Type: "character varying(50)", Nullable: true},
+						{Name: "Col_16", Type: "character varying(50)", Nullable: false},
 						{Name: "Col_17", Type: "date", Nullable: true},
 						{Name: "Col_18", Type: "date", Nullable: false},
 						{Name: "Col_19", Type: "double precision", Nullable: true},
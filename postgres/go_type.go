@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"github.com/Jumpaku/schenerate/gotype"
+)
+
+// NewGoTypeMapper returns a mapper from columns to Go types with the defaults of PostgreSQL.
+func NewGoTypeMapper(style gotype.NullStyle) *gotype.Mapper {
+	return gotype.NewMapper(DefaultGoType, style)
+}
+
+// DefaultGoType returns the default mapping of the PostgreSQL column type, which is supported by pgx.
+// Arrays are mapped to slices of the element types, which are unsupported if the element types are unknown.
+func DefaultGoType(columnType string, style gotype.NullStyle) (gotype.Mapping, bool) {
+	t, err := ParseColumnType(columnType)
+	if err != nil {
+		return gotype.Mapping{}, false
+	}
+	return defaultGoType(t, style)
+}
+
+func defaultGoType(t ColumnType, style gotype.NullStyle) (gotype.Mapping, bool) {
+	switch t.Base {
+	case "boolean":
+		return gotype.Nullable(style, gotype.Type{Name: "bool"}, sqlGoType("NullBool"), pgGoType("Bool")), true
+	case "smallint", "smallserial":
+		return gotype.Nullable(style, gotype.Type{Name: "int16"}, sqlGoType("NullInt16"), pgGoType("Int2")), true
+	case "integer", "serial":
+		return gotype.Nullable(style, gotype.Type{Name: "int32"}, sqlGoType("NullInt32"), pgGoType("Int4")), true
+	case "bigint", "bigserial":
+		return gotype.Nullable(style, gotype.Type{Name: "int64"}, sqlGoType("NullInt64"), pgGoType("Int8")), true
+	case "real":
+		return gotype.Nullable(style, gotype.Type{Name: "float32"}, gotype.Type{}, pgGoType("Float4")), true
+	case "double precision":
+		return gotype.Nullable(style, gotype.Type{Name: "float64"}, sqlGoType("NullFloat64"), pgGoType("Float8")), true
+	case "numeric":
+		return gotype.Same(pgGoType("Numeric")), true
+	case "character", "character varying", "text", "money", "xml", "time with time zone":
+		return gotype.Nullable(style, gotype.Type{Name: "string"}, sqlGoType("NullString"), pgGoType("Text")), true
+	case "uuid":
+		return gotype.Nullable(style, gotype.Type{Name: "string"}, sqlGoType("NullString"), pgGoType("UUID")), true
+	case "bytea":
+		return gotype.Same(gotype.Type{Name: "[]byte"}), true
+	case "json", "jsonb":
+		return gotype.Same(gotype.Type{Name: "json.RawMessage", Import: "encoding/json"}), true
+	case "bit", "bit varying":
+		return gotype.Same(pgGoType("Bits")), true
+	case "date":
+		return gotype.Nullable(style, gotype.Type{Name: "time.Time", Import: "time"}, sqlGoType("NullTime"), pgGoType("Date")), true
+	case "timestamp without time zone":
+		return gotype.Nullable(style, gotype.Type{Name: "time.Time", Import: "time"}, sqlGoType("NullTime"), pgGoType("Timestamp")), true
+	case "timestamp with time zone":
+		return gotype.Nullable(style, gotype.Type{Name: "time.Time", Import: "time"}, sqlGoType("NullTime"), pgGoType("Timestamptz")), true
+	case "time without time zone":
+		return gotype.Same(pgGoType("Time")), true
+	case "interval":
+		return gotype.Same(pgGoType("Interval")), true
+	case "array":
+		if t.Elem == nil {
+			return gotype.Mapping{}, false
+		}
+		elem, ok := defaultGoType(*t.Elem, style)
+		if !ok {
+			return gotype.Mapping{}, false
+		}
+		return gotype.Same(elem.Type.Slice()), true
+	default:
+		return gotype.Mapping{}, false
+	}
+}
+
+func pgGoType(name string) gotype.Type {
+	return gotype.Type{Name: "pgtype." + name, Import: "github.com/jackc/pgx/v5/pgtype"}
+}
+
+func sqlGoType(name string) gotype.Type {
+	return gotype.Type{Name: "sql." + name, Import: "database/sql"}
+}
@@ -0,0 +1,30 @@
+package postgres_test
+
+import (
+	"github.com/Jumpaku/schenerate/gotype"
+	"github.com/Jumpaku/schenerate/postgres"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDefaultGoType(t *testing.T) {
+	testcases := []struct {
+		in    string
+		style gotype.NullStyle
+		want  gotype.Mapping
+	}{
+		{in: "integer", style: gotype.NullStylePointer, want: gotype.Mapping{Type: gotype.Type{Name: "int32"}}},
+		{in: "character varying", style: gotype.NullStyleDatabaseSQL, want: gotype.Mapping{Type: gotype.Type{Name: "string"}, Null: gotype.Type{Name: "sql.NullString", Import: "database/sql"}}},
+		{in: "timestamp with time zone", style: gotype.NullStyleDriver, want: gotype.Mapping{Type: gotype.Type{Name: "time.Time", Import: "time"}, Null: gotype.Type{Name: "pgtype.Timestamptz", Import: "github.com/jackc/pgx/v5/pgtype"}}},
+		{in: "bytea", style: gotype.NullStyleDriver, want: gotype.Same(gotype.Type{Name: "[]byte"})},
+		{in: "real", style: gotype.NullStyleDatabaseSQL, want: gotype.Mapping{Type: gotype.Type{Name: "float32"}}},
+		{in: "text[]", style: gotype.NullStylePointer, want: gotype.Same(gotype.Type{Name: "[]string"})},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.in, func(t *testing.T) {
+			got, ok := postgres.DefaultGoType(testcase.in, testcase.style)
+			require.True(t, ok)
+			require.Equal(t, testcase.want, got)
+		})
+	}
+}
@@ -3,33 +3,81 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"github.com/Jumpaku/schenerate/schema"
 	"github.com/samber/lo"
 	"slices"
+	"strings"
 )
 
-func ListSchemas(ctx context.Context, q queryer, tables []string) (schemas Schemas, err error) {
+// ListSchemas lists the schemas of the tables, which are expanded by the references and the referrers according to opts.
+// The names of the tables may be qualified by the schemas, otherwise they are resolved in the order of the search path.
+func ListSchemas(ctx context.Context, q Queryer, tables []string, opts ...schema.ListOption) (Schemas, error) {
+	return schema.Expand(ctx, tables, opts,
+		func(ctx context.Context, tables []string) ([]Schema, error) { return listSchemas(ctx, q, tables) },
+		func(s Schema) schema.Schema { return Schemas{s}.Unified()[0] },
+		func(ctx context.Context) (map[string][]string, error) { return listReferences(ctx, q) },
+	)
+}
+
+// listReferences returns the tables referenced by each table through foreign keys by a single query,
+// where the tables are identified by the names qualified by their schemas.
+func listReferences(ctx context.Context, q Queryer) (map[string][]string, error) {
+	type reference struct {
+		Schema           string `db:"Schema"`
+		Name             string `db:"Name"`
+		ReferencedSchema string `db:"ReferencedSchema"`
+		ReferencedTable  string `db:"ReferencedTable"`
+	}
+	rows, err := query[reference](ctx, q,
+		//language=SQL
+		`--sql query references of all the tables
+SELECT DISTINCT
+    tc.table_schema AS "Schema",
+    tc.table_name AS "Name",
+    ctu.table_schema AS "ReferencedSchema",
+    ctu.table_name AS "ReferencedTable"
+FROM
+    information_schema.table_constraints tc
+        JOIN information_schema.referential_constraints rc
+            ON rc.constraint_schema = tc.constraint_schema AND rc.constraint_name = tc.constraint_name
+        JOIN information_schema.constraint_table_usage ctu
+            ON ctu.constraint_schema = rc.unique_constraint_schema AND ctu.constraint_name = rc.unique_constraint_name
+WHERE tc.constraint_type = 'FOREIGN KEY'
+ORDER BY "Schema", "Name", "ReferencedSchema", "ReferencedTable";`)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get references: %w`, err)
+	}
+	refs := map[string][]string{}
+	for _, r := range rows {
+		name := r.Schema + "." + r.Name
+		refs[name] = append(refs[name], r.ReferencedSchema+"."+r.ReferencedTable)
+	}
+	return refs, nil
+}
+
+func listSchemas(ctx context.Context, q Queryer, tables []string) (schemas Schemas, err error) {
 	for _, t := range tables {
 		schema, err := queryTable(ctx, q, t)
 		if err != nil {
 			return nil, fmt.Errorf(`fail to get schema of %s: %w`, t, err)
 		}
-		schema.Columns, err = queryColumns(ctx, q, t)
+		schema.Columns, err = queryColumns(ctx, q, schema.Schema, schema.Name)
 		if err != nil {
 			return nil, fmt.Errorf(`fail to get columns of %s: %w`, t, err)
 		}
-		schema.PrimaryKey, err = queryPrimaryKey(ctx, q, t)
+		schema.PrimaryKey, schema.PrimaryKeyName, err = queryPrimaryKey(ctx, q, schema.Schema, schema.Name)
 		if err != nil {
 			return nil, fmt.Errorf(`fail to get primary key of %s: %w`, t, err)
 		}
-		schema.ForeignKeys, err = queryForeignKeys(ctx, q, t)
+		schema.ForeignKeys, err = queryForeignKeys(ctx, q, schema.Schema, schema.Name)
 		if err != nil {
 			return nil, fmt.Errorf(`fail to get foreign keys of %s: %w`, t, err)
 		}
-		schema.UniqueKeys, err = queryUniqueKeys(ctx, q, t)
+		schema.UniqueKeys, err = queryUniqueKeys(ctx, q, schema.Schema, schema.Name)
 		if err != nil {
 			return nil, fmt.Errorf(`fail to get unique keys of %s: %w`, t, err)
 		}
-		schema.Indexes, err = queryIndexes(ctx, q, t)
+		schema.Indexes, err = queryIndexes(ctx, q, schema.Schema, schema.Name)
 		if err != nil {
 			return nil, fmt.Errorf(`fail to get indexes of %s: %w`, t, err)
 		}
@@ -40,12 +88,16 @@ func ListSchemas(ctx context.Context, q queryer, tables []string) (schemas Schem
 	return schemas, nil
 }
 
-func queryTable(ctx context.Context, q queryer, table string) (Schema, error) {
+func queryTable(ctx context.Context, q Queryer, table string) (Schema, error) {
 	type recordTable struct {
 		Schema string `db:"Schema"`
 		Name   string `db:"Name"`
 		Type   string `db:"Type"`
 	}
+	schemaName, name, ok := strings.Cut(table, ".")
+	if !ok {
+		schemaName, name = "", table
+	}
 	rows, err := query[recordTable](ctx, q,
 		//language=SQL
 		`--sql query table information
@@ -54,8 +106,8 @@ SELECT
 	table_name AS "Name",
 	table_type AS "Type"
 FROM information_schema.tables
-WHERE table_name = $1
-ORDER BY table_name`, table)
+WHERE table_name = $1 AND ($2::text = '' OR table_schema::text = $2::text)
+ORDER BY array_position(current_schemas(false), table_schema::name) NULLS LAST, table_schema`, name, schemaName)
 	if err != nil {
 		return Schema{}, fmt.Errorf(`fail to get table %s: %w`, table, err)
 	}
@@ -68,7 +120,7 @@ ORDER BY table_name`, table)
 	return Schema{Schema: record.Schema, Name: record.Name, Type: record.Type}, nil
 }
 
-func queryColumns(ctx context.Context, q queryer, table string) ([]Column, error) {
+func queryColumns(ctx context.Context, q Queryer, schemaName, table string) ([]Column, error) {
 	type column struct {
 		Name     string `db:"Name"`
 		Type     string `db:"Type"`
@@ -77,13 +129,15 @@ func queryColumns(ctx context.Context, q queryer, table string) ([]Column, error
 	rows, err := query[column](ctx, q,
 		//language=SQL
 		`--sql query column information
-SELECT 
-	column_name AS "Name",
-	data_type AS "Type",
-	is_nullable = 'YES' AS "Nullable"
-FROM information_schema.columns
-WHERE table_name = $1
-ORDER BY ordinal_position`, table)
+SELECT
+	a.attname AS "Name",
+	format_type(a.atttypid, a.atttypmod) AS "Type",
+	NOT a.attnotnull AS "Nullable"
+FROM pg_catalog.pg_attribute AS a
+	JOIN pg_catalog.pg_class AS c ON a.attrelid = c.oid
+	JOIN pg_catalog.pg_namespace AS n ON c.relnamespace = n.oid
+WHERE n.nspname = $1 AND c.relname = $2 AND a.attnum > 0 AND NOT a.attisdropped
+ORDER BY a.attnum`, schemaName, table)
 	if err != nil {
 		return nil, fmt.Errorf(`fail to get columns of %s: %w`, table, err)
 	}
@@ -93,27 +147,33 @@ ORDER BY ordinal_position`, table)
 	}), nil
 }
 
-func queryPrimaryKey(ctx context.Context, q queryer, table string) ([]string, error) {
-	type name struct {
-		Name string `db:"Name"`
+func queryPrimaryKey(ctx context.Context, q Queryer, schemaName, table string) ([]string, string, error) {
+	type keyColumn struct {
+		Constraint string `db:"Constraint"`
+		Name       string `db:"Name"`
 	}
-	rows, err := query[name](ctx, q,
+	rows, err := query[keyColumn](ctx, q,
 		//language=SQL
 		`--sql query primary key information
 SELECT
+    tc.constraint_name AS "Constraint",
     kcu.column_name AS "Name"
 FROM information_schema.table_constraints AS tc
     JOIN information_schema.key_column_usage AS kcu
-    	ON kcu.constraint_name = tc.constraint_name
-WHERE kcu.table_name = $1 AND tc.constraint_type = 'PRIMARY KEY'
-ORDER BY kcu.ordinal_position`, table)
+    	ON kcu.constraint_schema = tc.constraint_schema AND kcu.constraint_name = tc.constraint_name
+WHERE kcu.table_schema = $1 AND kcu.table_name = $2 AND tc.constraint_type = 'PRIMARY KEY'
+ORDER BY kcu.ordinal_position`, schemaName, table)
 	if err != nil {
-		return nil, fmt.Errorf(`fail to get primary key of %s: %w`, table, err)
+		return nil, "", fmt.Errorf(`fail to get primary key of %s: %w`, table, err)
+	}
+	constraint := ""
+	if len(rows) > 0 {
+		constraint = rows[0].Constraint
 	}
-	return lo.Map(rows, func(item name, _ int) string { return item.Name }), nil
+	return lo.Map(rows, func(item keyColumn, _ int) string { return item.Name }), constraint, nil
 }
 
-func queryForeignKeys(ctx context.Context, q queryer, table string) ([]ForeignKey, error) {
+func queryForeignKeys(ctx context.Context, q Queryer, schemaName, table string) ([]ForeignKey, error) {
 	type fkRow struct {
 		Name             string `db:"Name"`
 		ReferencedSchema string `db:"ReferencedSchema"`
@@ -133,16 +193,16 @@ SELECT
 FROM
     information_schema.table_constraints tc
         JOIN information_schema.referential_constraints rc
-            ON rc.constraint_name = tc.constraint_name
+            ON rc.constraint_schema = tc.constraint_schema AND rc.constraint_name = tc.constraint_name
         JOIN information_schema.constraint_table_usage ctu
-            ON ctu.constraint_name = rc.unique_constraint_name
+            ON ctu.constraint_schema = rc.unique_constraint_schema AND ctu.constraint_name = rc.unique_constraint_name
         JOIN information_schema.key_column_usage kcu1
-            ON kcu1.constraint_name = rc.constraint_name
+            ON kcu1.constraint_schema = rc.constraint_schema AND kcu1.constraint_name = rc.constraint_name
         JOIN information_schema.key_column_usage kcu2
-            ON kcu2.constraint_name = rc.unique_constraint_name
+            ON kcu2.constraint_schema = rc.unique_constraint_schema AND kcu2.constraint_name = rc.unique_constraint_name
                 AND kcu2.ordinal_position = kcu1.ordinal_position
-WHERE tc.constraint_type = 'FOREIGN KEY' AND tc.table_name = $1
-ORDER BY "Name", kcu1.ordinal_position;`, table)
+WHERE tc.constraint_type = 'FOREIGN KEY' AND tc.table_schema = $1 AND tc.table_name = $2
+ORDER BY "Name", kcu1.ordinal_position;`, schemaName, table)
 	if err != nil {
 		return nil, fmt.Errorf(`fail to get foreign keys of %s: %w`, table, err)
 	}
@@ -168,7 +228,7 @@ ORDER BY "Name", kcu1.ordinal_position;`, table)
 	return foreignKeys, nil
 }
 
-func queryUniqueKeys(ctx context.Context, q queryer, table string) ([]UniqueKey, error) {
+func queryUniqueKeys(ctx context.Context, q Queryer, schemaName, table string) ([]UniqueKey, error) {
 	type ukRow struct {
 		Name       string `db:"Name"`
 		ColumnName string `db:"ColumnName"`
@@ -182,9 +242,9 @@ SELECT
     kcu.column_name AS "ColumnName"
 FROM information_schema.table_constraints AS tc
 	 JOIN information_schema.key_column_usage AS kcu
-		  ON kcu.constraint_name = tc.constraint_name
-WHERE kcu.table_name = $1 AND tc.constraint_type = 'UNIQUE'
-ORDER BY tc.constraint_name, kcu.ordinal_position;`, table)
+		  ON kcu.constraint_schema = tc.constraint_schema AND kcu.constraint_name = tc.constraint_name
+WHERE kcu.table_schema = $1 AND kcu.table_name = $2 AND tc.constraint_type = 'UNIQUE'
+ORDER BY tc.constraint_name, kcu.ordinal_position;`, schemaName, table)
 	if err != nil {
 		return nil, fmt.Errorf(`fail to get unique keys of %s: %w`, table, err)
 	}
@@ -205,7 +265,7 @@ ORDER BY tc.constraint_name, kcu.ordinal_position;`, table)
 	return uniqueKeys, nil
 }
 
-func queryIndexes(ctx context.Context, q queryer, table string) ([]Index, error) {
+func queryIndexes(ctx context.Context, q Queryer, schemaName, table string) ([]Index, error) {
 	type idxRow struct {
 		Name      string `db:"Name"`
 		Unique    bool   `db:"Unique"`
@@ -220,9 +280,10 @@ SELECT cls.relname  AS "Name",
 FROM pg_catalog.pg_index AS idx
          JOIN pg_catalog.pg_class AS cls ON idx.indexrelid = cls.oid
          JOIN pg_catalog.pg_class AS tbl ON idx.indrelid = tbl.oid
+         JOIN pg_catalog.pg_namespace AS ns ON tbl.relnamespace = ns.oid
          JOIN pg_catalog.pg_attribute AS attr ON idx.indexrelid = attr.attrelid
-WHERE tbl.relname = $1 AND NOT idx.indisprimary
-ORDER BY cls.relname, attr.attnum;`, table)
+WHERE ns.nspname = $1 AND tbl.relname = $2 AND NOT idx.indisprimary
+ORDER BY cls.relname, attr.attnum;`, schemaName, table)
 	if err != nil {
 		return nil, fmt.Errorf(`fail to get unique keys of %s: %w`, table, err)
 	}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"github.com/Jumpaku/schenerate/schema"
 	"github.com/samber/lo"
 )
 
@@ -10,19 +11,22 @@ type Table struct {
 	Catalog string
 	Schema  string
 	Name    string
+	Type    string
 }
 
-func ListTables(ctx context.Context, q queryer) ([]Table, error) {
+func ListTables(ctx context.Context, q Queryer) ([]Table, error) {
 	type table struct {
 		Catalog string `db:"Catalog"`
 		Schema  string `db:"Schema"`
 		Name    string `db:"Name"`
+		Type    string `db:"Type"`
 	}
 	records, err := query[table](ctx, q,
 		`SELECT
     table_catalog AS "Catalog",
     table_schema AS "Schema",
-	table_name AS "Name"
+	table_name AS "Name",
+	table_type AS "Type"
 FROM information_schema.tables
 WHERE table_schema NOT IN ('information_schema', 'pg_catalog')
 ORDER BY table_catalog, table_schema, table_name`)
@@ -34,6 +38,20 @@ ORDER BY table_catalog, table_schema, table_name`)
 			Catalog: r.Catalog,
 			Schema:  r.Schema,
 			Name:    r.Name,
+			Type:    r.Type,
 		}
 	}), nil
 }
+
+// SelectTables returns the names of the tables selected by the selector qualified by their schemas, which can be passed to ListSchemas.
+func SelectTables(ctx context.Context, q Queryer, selector schema.Selector) ([]string, error) {
+	tables, err := q.ListTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+	selected, err := selector.Select(tables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select tables: %w", err)
+	}
+	return lo.Map(selected, func(t schema.Table, _ int) string { return t.Schema + "." + t.Name }), nil
+}
@@ -5,6 +5,8 @@ import (
 	_ "embed"
 	"fmt"
 	"github.com/Jumpaku/schenerate/postgres"
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 	"testing"
 	"time"
@@ -66,6 +68,28 @@ func TestTableLister_List(t *testing.T) {
 	}
 }
 
+func TestSelectTables_qualified(t *testing.T) {
+	database := fmt.Sprintf(`selecttables_%d`, time.Now().Unix())
+	q, teardown := postgres.Setup(t, database, []string{`
+CREATE SCHEMA a;
+CREATE SCHEMA b;
+CREATE TABLE a.users (id INTEGER PRIMARY KEY);
+CREATE TABLE b.users (id INTEGER PRIMARY KEY, a_id INTEGER REFERENCES a.users (id));`})
+	defer teardown()
+
+	got, err := postgres.SelectTables(context.Background(), q, schema.Selector{Schemas: []string{"b"}})
+	require.Nil(t, err)
+	require.Equal(t, []string{"b.users"}, got)
+
+	schemas, err := postgres.ListSchemas(context.Background(), q, []string{"b.users"}, schema.WithReferences(-1))
+	require.Nil(t, err)
+	require.Len(t, schemas, 2)
+	require.Equal(t, "b", schemas[0].Schema)
+	require.Equal(t, []string{"id", "a_id"}, lo.Map(schemas[0].Columns, func(c postgres.Column, _ int) string { return c.Name }))
+	require.Equal(t, "a", schemas[1].Schema)
+	require.Equal(t, []string{"id"}, lo.Map(schemas[1].Columns, func(c postgres.Column, _ int) string { return c.Name }))
+}
+
 //go:embed testdata/list/ddl_00_all_types.sql
 var list_ddl00AllTypes string
 
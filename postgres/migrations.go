@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/Jumpaku/schenerate/migrations"
+	"github.com/samber/lo"
+)
+
+// ListSchemasFromMigrations applies the up-migrations in the directory up to and including the version to the database in a transaction
+// and returns the schemas of the tables created by the migrations, where all the migrations are applied if the version is empty.
+// The tables existing in the database before the migrations are excluded even if the migrations alter them.
+// The transaction is always rolled back, so the migrations must be able to run in a transaction.
+func ListSchemasFromMigrations(ctx context.Context, q Queryer, dir string, version string) (schemas Schemas, err error) {
+	ms, err := migrations.Load(dir)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to load migrations: %w`, err)
+	}
+	ms, err = ms.UpTo(version)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to select migrations: %w`, err)
+	}
+
+	tx, err := q.conn.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to begin transaction: %w`, err)
+	}
+	defer tx.Rollback(ctx)
+
+	existing, err := ListTables(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to list tables: %w`, err)
+	}
+	for _, m := range ms {
+		if _, err := tx.Exec(ctx, m.Up); err != nil {
+			return nil, fmt.Errorf(`fail to apply migration %s: %w`, m.Path, err)
+		}
+	}
+
+	tables, err := ListTables(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to list tables: %w`, err)
+	}
+	created := lo.Reject(tables, func(t Table, _ int) bool {
+		return lo.ContainsBy(existing, func(e Table) bool { return e.Schema == t.Schema && e.Name == t.Name })
+	})
+	return ListSchemas(ctx, q, lo.Map(created, func(t Table, _ int) string { return t.Schema + "." + t.Name }))
+}
@@ -0,0 +1,48 @@
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/Jumpaku/schenerate/postgres"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestListSchemasFromMigrations(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"00001_create_a.sql": "-- +goose Up\nCREATE TABLE a (id INTEGER PRIMARY KEY);\n-- +goose Down\nDROP TABLE a;\n",
+		"00002_create_b.sql": "-- +goose Up\nCREATE TABLE b (id INTEGER PRIMARY KEY, a_id INTEGER REFERENCES a (id));\n-- +goose Down\nDROP TABLE b;\n",
+	} {
+		require.Nil(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	testcases := []struct {
+		name       string
+		existing   []string
+		version    string
+		wantTables []string
+	}{
+		{name: "latest", version: "", wantTables: []string{"a", "b"}},
+		{name: "version 1", version: "1", wantTables: []string{"a"}},
+		{name: "existing tables excluded", existing: []string{"CREATE TABLE z (id INTEGER PRIMARY KEY)"}, version: "", wantTables: []string{"a", "b"}},
+	}
+	for number, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			q, teardown := postgres.Setup(t, fmt.Sprintf(`migrations_%d_%d`, number, time.Now().Unix()), testcase.existing)
+			defer teardown()
+
+			got, err := postgres.ListSchemasFromMigrations(context.Background(), q, dir, testcase.version)
+			require.Nil(t, err)
+			require.Equal(t, testcase.wantTables, lo.Map(got, func(s postgres.Schema, _ int) string { return s.Name }))
+
+			tables, err := postgres.ListTables(context.Background(), q)
+			require.Nil(t, err)
+			require.Len(t, tables, len(testcase.existing), "migrations should be rolled back")
+		})
+	}
+}
@@ -3,26 +3,80 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"github.com/Jumpaku/schenerate/internal/slice"
+	"github.com/Jumpaku/schenerate/schema"
 	"github.com/jackc/pgx/v5"
 )
 
-type queryer struct {
+// Queryer queries a Postgres database, which implements schema.Queryer.
+type Queryer struct {
 	conn *pgx.Conn
 }
 
-func Open(connStr string) (queryer, error) {
+func Open(connStr string) (Queryer, error) {
 	conn, err := pgx.Connect(context.Background(), connStr)
 	if err != nil {
-		return queryer{}, fmt.Errorf("failed to connect: %w", err)
+		return Queryer{}, fmt.Errorf("failed to connect: %w", err)
 	}
-	return queryer{conn: conn}, nil
+	return Queryer{conn: conn}, nil
 }
 
-func (q queryer) Close() error {
+func (q Queryer) Close() error {
 	return q.conn.Close(context.Background())
 }
 
-func query[Record any](ctx context.Context, q queryer, stmt string, args ...any) (records []Record, err error) {
+var _ schema.Queryer = Queryer{}
+
+func (q Queryer) ListTables(ctx context.Context) ([]schema.Table, error) {
+	tables, err := ListTables(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(tables, func(t Table) schema.Table {
+		return schema.Table{Catalog: t.Catalog, Schema: t.Schema, Name: t.Name, Type: t.Type}
+	}), nil
+}
+
+// SelectTables returns the names of the tables selected by the selector qualified by their schemas, which is used by introspect.SelectTables.
+func (q Queryer) SelectTables(ctx context.Context, selector schema.Selector) ([]string, error) {
+	return SelectTables(ctx, q, selector)
+}
+
+// ListReferences returns the tables referenced by each table by a single query, which is used by introspect.ListSchemas.
+func (q Queryer) ListReferences(ctx context.Context) (map[string][]string, error) {
+	return listReferences(ctx, q)
+}
+
+func (q Queryer) ListSchemas(ctx context.Context, tables []string) (schema.Schemas, error) {
+	schemas, err := ListSchemas(ctx, q, tables)
+	if err != nil {
+		return nil, err
+	}
+	return schemas.Unified(), nil
+}
+
+func (q Queryer) QueryRows(ctx context.Context, stmt string, params []any) (records []schema.Row, err error) {
+	rows, err := q.conn.Query(ctx, stmt, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		record, err := pgx.RowToMap(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan: %w", err)
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+
+	return records, nil
+}
+
+func query[Record any](ctx context.Context, q Queryer, stmt string, args ...any) (records []Record, err error) {
 	rows, err := q.conn.Query(ctx, stmt, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query: %w", err)
@@ -29,45 +29,47 @@ func (s Schemas) BuildGraph() graph.Graph[Schema] {
 }
 
 type Schema struct {
-	Schema      string
-	Name        string
-	Type        string
-	Columns     []Column
-	PrimaryKey  []string
-	ForeignKeys []ForeignKey
-	UniqueKeys  []UniqueKey
-	Indexes     []Index
+	Schema     string   `json:"schema" yaml:"schema"`
+	Name       string   `json:"name" yaml:"name"`
+	Type       string   `json:"type" yaml:"type"`
+	Columns    []Column `json:"columns" yaml:"columns"`
+	PrimaryKey []string `json:"primaryKey" yaml:"primaryKey"`
+	// PrimaryKeyName is the name of the primary key constraint, which is empty if unknown.
+	PrimaryKeyName string       `json:"primaryKeyName,omitempty" yaml:"primaryKeyName,omitempty"`
+	ForeignKeys    []ForeignKey `json:"foreignKeys" yaml:"foreignKeys"`
+	UniqueKeys     []UniqueKey  `json:"uniqueKeys" yaml:"uniqueKeys"`
+	Indexes        []Index      `json:"indexes" yaml:"indexes"`
 }
 
 type Column struct {
-	Name     string
-	Type     string
-	Nullable bool
+	Name     string `json:"name" yaml:"name"`
+	Type     string `json:"type" yaml:"type"`
+	Nullable bool   `json:"nullable" yaml:"nullable"`
 }
 
 type ForeignKey struct {
-	Name      string
-	Key       []string
-	Reference ForeignKeyReference
+	Name      string              `json:"name" yaml:"name"`
+	Key       []string            `json:"key" yaml:"key"`
+	Reference ForeignKeyReference `json:"reference" yaml:"reference"`
 }
 
 type ForeignKeyReference struct {
-	Schema string
-	Table  string
-	Key    []string
+	Schema string   `json:"schema" yaml:"schema"`
+	Table  string   `json:"table" yaml:"table"`
+	Key    []string `json:"key" yaml:"key"`
 }
 
 type UniqueKey struct {
-	Name string
-	Key  []string
+	Name string   `json:"name" yaml:"name"`
+	Key  []string `json:"key" yaml:"key"`
 }
 type Index struct {
-	Name   string
-	Unique bool
-	Key    []string
+	Name   string   `json:"name" yaml:"name"`
+	Unique bool     `json:"unique" yaml:"unique"`
+	Key    []string `json:"key" yaml:"key"`
 }
 
 type IndexKeyElem struct {
-	Name string
-	Desc bool
+	Name string `json:"name" yaml:"name"`
+	Desc bool   `json:"desc" yaml:"desc"`
 }
@@ -10,12 +10,12 @@ import (
 
 var dataSource = flag.String("data-source", "", "-data-source=<data source>")
 
-func Setup(t *testing.T, database string, ddls []string) (q queryer, teardown func()) {
+func Setup(t *testing.T, database string, ddls []string) (q Queryer, teardown func()) {
 	t.Helper()
 
 	if *dataSource == "" {
 		t.Skip(`postgres dataSource is required`)
-		return queryer{}, nil
+		return Queryer{}, nil
 	}
 
 	dataSource := *dataSource
@@ -67,5 +67,5 @@ func Setup(t *testing.T, database string, ddls []string) (q queryer, teardown fu
 	teardown = func() {
 		db.Close(ctx)
 	}
-	return queryer{conn: db}, teardown
+	return Queryer{conn: db}, teardown
 }
@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/Jumpaku/schenerate/snapshot"
+	"slices"
+	"strings"
+)
+
+const snapshotDialect = string(schema.DialectPostgres)
+
+// MarshalSnapshot serializes the schemas into a snapshot in the format.
+// Tables, constraints and indexes are sorted by their names so that the snapshot is deterministic, while the orders of columns and keys are preserved.
+func (s Schemas) MarshalSnapshot(format snapshot.Format) ([]byte, error) {
+	return snapshot.Marshal(format, snapshotDialect, s.sorted())
+}
+
+// SaveSnapshot writes the schemas to a snapshot file in the format determined by the extension of the path.
+func (s Schemas) SaveSnapshot(path string) error {
+	return snapshot.Save(path, snapshotDialect, s.sorted())
+}
+
+// UnmarshalSnapshot deserializes schemas from a snapshot in the format.
+func UnmarshalSnapshot(format snapshot.Format, b []byte) (Schemas, error) {
+	return snapshot.Unmarshal[Schemas](format, snapshotDialect, b)
+}
+
+// LoadSnapshot reads schemas from a snapshot file in the format determined by the extension of the path.
+func LoadSnapshot(path string) (Schemas, error) {
+	return snapshot.Load[Schemas](path, snapshotDialect)
+}
+
+func (s Schemas) sorted() Schemas {
+	if s == nil {
+		return nil
+	}
+	sorted := make(Schemas, len(s))
+	for i, t := range s {
+		t.ForeignKeys = slices.Clone(t.ForeignKeys)
+		slices.SortStableFunc(t.ForeignKeys, func(a, b ForeignKey) int { return strings.Compare(a.Name, b.Name) })
+		t.UniqueKeys = slices.Clone(t.UniqueKeys)
+		slices.SortStableFunc(t.UniqueKeys, func(a, b UniqueKey) int { return strings.Compare(a.Name, b.Name) })
+		t.Indexes = slices.Clone(t.Indexes)
+		slices.SortStableFunc(t.Indexes, func(a, b Index) int { return strings.Compare(a.Name, b.Name) })
+		sorted[i] = t
+	}
+	slices.SortStableFunc(sorted, func(a, b Schema) int {
+		if c := strings.Compare(a.Schema, b.Schema); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return sorted
+}
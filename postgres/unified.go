@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"fmt"
+	"github.com/Jumpaku/schenerate/internal/slice"
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/samber/lo"
+)
+
+// Unified converts the schemas into the dialect-neutral schemas.
+func (s Schemas) Unified() schema.Schemas {
+	return slice.Map(s, func(s Schema) schema.Schema {
+		return schema.Schema{
+			Dialect: schema.DialectPostgres,
+			Schema:  s.Schema,
+			Name:    s.Name,
+			Type:    s.Type,
+			Columns: slice.Map(s.Columns, func(c Column) schema.Column {
+				return schema.Column{Name: c.Name, Type: c.Type, Nullable: c.Nullable}
+			}),
+			PrimaryKey:     s.PrimaryKey,
+			PrimaryKeyName: s.PrimaryKeyName,
+			ForeignKeys: slice.Map(s.ForeignKeys, func(fk ForeignKey) schema.ForeignKey {
+				return schema.ForeignKey{
+					Name: fk.Name,
+					Key:  fk.Key,
+					Reference: schema.ForeignKeyReference{
+						Schema: fk.Reference.Schema,
+						Table:  fk.Reference.Table,
+						Key:    fk.Reference.Key,
+					},
+				}
+			}),
+			UniqueKeys: slice.Map(s.UniqueKeys, func(uk UniqueKey) schema.UniqueKey {
+				return schema.UniqueKey{Name: uk.Name, Key: uk.Key}
+			}),
+			Indexes: slice.Map(s.Indexes, func(idx Index) schema.Index {
+				return schema.Index{
+					Name:   idx.Name,
+					Unique: idx.Unique,
+					Key: slice.Map(idx.Key, func(k string) schema.IndexKeyElem {
+						return schema.IndexKeyElem{Name: k}
+					}),
+				}
+			}),
+		}
+	})
+}
+
+// FromUnified converts the dialect-neutral schemas into the schemas of Postgres.
+func FromUnified(schemas schema.Schemas) (Schemas, error) {
+	if schemas == nil {
+		return nil, nil
+	}
+	result := Schemas{}
+	for _, s := range schemas {
+		if s.Dialect != schema.DialectPostgres {
+			return nil, fmt.Errorf(`fail to convert schema of %s: dialect %q is not %q`, s.Name, s.Dialect, schema.DialectPostgres)
+		}
+		if s.Parent != "" {
+			return nil, fmt.Errorf(`fail to convert schema of %s: parent is not supported`, s.Name)
+		}
+		for _, idx := range s.Indexes {
+			if idx.Origin != "" {
+				return nil, fmt.Errorf(`fail to convert index %s of %s: origin is not supported`, idx.Name, s.Name)
+			}
+			if lo.ContainsBy(idx.Key, func(k schema.IndexKeyElem) bool { return k.Desc }) {
+				return nil, fmt.Errorf(`fail to convert index %s of %s: descending key is not supported`, idx.Name, s.Name)
+			}
+		}
+		result = append(result, Schema{
+			Schema: s.Schema,
+			Name:   s.Name,
+			Type:   s.Type,
+			Columns: slice.Map(s.Columns, func(c schema.Column) Column {
+				return Column{Name: c.Name, Type: c.Type, Nullable: c.Nullable}
+			}),
+			PrimaryKey:     s.PrimaryKey,
+			PrimaryKeyName: s.PrimaryKeyName,
+			ForeignKeys: slice.Map(s.ForeignKeys, func(fk schema.ForeignKey) ForeignKey {
+				return ForeignKey{
+					Name: fk.Name,
+					Key:  fk.Key,
+					Reference: ForeignKeyReference{
+						Schema: fk.Reference.Schema,
+						Table:  fk.Reference.Table,
+						Key:    fk.Reference.Key,
+					},
+				}
+			}),
+			UniqueKeys: slice.Map(s.UniqueKeys, func(uk schema.UniqueKey) UniqueKey {
+				return UniqueKey{Name: uk.Name, Key: uk.Key}
+			}),
+			Indexes: slice.Map(s.Indexes, func(idx schema.Index) Index {
+				return Index{
+					Name:   idx.Name,
+					Unique: idx.Unique,
+					Key:    slice.Map(idx.Key, func(k schema.IndexKeyElem) string { return k.Name }),
+				}
+			}),
+		})
+	}
+	return result, nil
+}
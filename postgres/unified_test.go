@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSchemas_Unified(t *testing.T) {
+	sut := Schemas{
+		{
+			Schema: "public",
+			Name:   "H",
+			Type:   "BASE TABLE",
+			Columns: []Column{
+				{Name: "PK", Type: "integer", Nullable: false},
+				{Name: "C1", Type: "integer", Nullable: true},
+			},
+			PrimaryKey: []string{"PK"},
+			UniqueKeys: []UniqueKey{{Name: "UQ_H_C1", Key: []string{"C1"}}},
+			Indexes:    []Index{{Name: "UQ_H_C1", Unique: true, Key: []string{"C1"}}},
+		},
+		{
+			Schema:     "public",
+			Name:       "C_2",
+			Type:       "BASE TABLE",
+			Columns:    []Column{{Name: "PK_21", Type: "integer"}},
+			PrimaryKey: []string{"PK_21"},
+			ForeignKeys: []ForeignKey{
+				{Name: "FK_C_2_1", Key: []string{"PK_21"}, Reference: ForeignKeyReference{Schema: "public", Table: "H", Key: []string{"PK"}}},
+			},
+		},
+	}
+
+	got := sut.Unified()
+
+	require.Equal(t, schema.Schemas{
+		{
+			Dialect: schema.DialectPostgres,
+			Schema:  "public",
+			Name:    "H",
+			Type:    "BASE TABLE",
+			Columns: []schema.Column{
+				{Name: "PK", Type: "integer", Nullable: false},
+				{Name: "C1", Type: "integer", Nullable: true},
+			},
+			PrimaryKey: []string{"PK"},
+			UniqueKeys: []schema.UniqueKey{{Name: "UQ_H_C1", Key: []string{"C1"}}},
+			Indexes:    []schema.Index{{Name: "UQ_H_C1", Unique: true, Key: []schema.IndexKeyElem{{Name: "C1"}}}},
+		},
+		{
+			Dialect:    schema.DialectPostgres,
+			Schema:     "public",
+			Name:       "C_2",
+			Type:       "BASE TABLE",
+			Columns:    []schema.Column{{Name: "PK_21", Type: "integer"}},
+			PrimaryKey: []string{"PK_21"},
+			ForeignKeys: []schema.ForeignKey{
+				{Name: "FK_C_2_1", Key: []string{"PK_21"}, Reference: schema.ForeignKeyReference{Schema: "public", Table: "H", Key: []string{"PK"}}},
+			},
+		},
+	}, got)
+
+	back, err := FromUnified(got)
+	require.Nil(t, err)
+	require.Equal(t, sut, back)
+}
+
+func TestFromUnified_Error(t *testing.T) {
+	_, err := FromUnified(schema.Schemas{{Dialect: schema.DialectSpanner, Name: "A"}})
+	require.Error(t, err)
+	_, err = FromUnified(schema.Schemas{{Dialect: schema.DialectPostgres, Name: "A", Indexes: []schema.Index{{Name: "I", Key: []schema.IndexKeyElem{{Name: "C", Desc: true}}}}}})
+	require.Error(t, err)
+}
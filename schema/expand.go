@@ -0,0 +1,172 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"github.com/samber/lo"
+	"slices"
+)
+
+// ListOption configures ListSchemas of the backends.
+type ListOption func(*listOptions)
+
+type listOptions struct {
+	references int
+	referrers  int
+}
+
+// WithReferences also lists the tables referenced by the listed tables through foreign keys and interleaving
+// up to depth hops, or without limit if depth is negative.
+func WithReferences(depth int) ListOption {
+	return func(o *listOptions) { o.references = depth }
+}
+
+// WithReferrers also lists the tables referencing the given tables through foreign keys and interleaving
+// up to depth hops, or without limit if depth is negative.
+func WithReferrers(depth int) ListOption {
+	return func(o *listOptions) { o.referrers = depth }
+}
+
+// Expand lists the items of the tables by list, which is expanded according to the options as follows:
+//
+//  1. The tables are expanded by the referrers of the tables up to the depth of WithReferrers.
+//  2. The expanded tables are expanded by the references of the tables up to the depth of WithReferences.
+//
+// Thus, the result is closed under references if WithReferences is negative, which can be passed to BuildGraph.
+// The items are in the order of the tables followed by the tables in the order in which they are found.
+// The items are identified by the names qualified by their schemas if any, and the tables may be either qualified or not.
+// unified converts an item into a Schema to find its references, and references lists the tables referenced by each of all the tables
+// as Schemas.References, e.g. by a query of the catalog of the foreign keys, which is called only for referrers.
+// It is a building block of ListSchemas of the backends.
+func Expand[T any](
+	ctx context.Context,
+	tables []string,
+	opts []ListOption,
+	list func(ctx context.Context, tables []string) ([]T, error),
+	unified func(item T) Schema,
+	references func(ctx context.Context) (map[string][]string, error),
+) ([]T, error) {
+	var o listOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.references == 0 && o.referrers == 0 {
+		return list(ctx, tables)
+	}
+
+	items := map[string]T{}
+	// aliases maps the names of the tables to the qualified names of the listed items.
+	aliases := map[string]string{}
+	resolve := func(t string) string {
+		if k, ok := aliases[t]; ok {
+			return k
+		}
+		return t
+	}
+	load := func(tables []string) error {
+		var missing []string
+		for _, t := range tables {
+			if _, ok := items[resolve(t)]; !ok {
+				missing = append(missing, t)
+			}
+		}
+		if len(missing) == 0 {
+			return nil
+		}
+		listed, err := list(ctx, missing)
+		if err != nil {
+			return err
+		}
+		for _, item := range listed {
+			items[qualifiedName(unified(item))] = item
+		}
+		for _, t := range missing {
+			if _, ok := items[t]; ok {
+				continue
+			}
+			matched := lo.Filter(listed, func(item T, _ int) bool { return unified(item).Name == t })
+			if len(matched) == 1 {
+				aliases[t] = qualifiedName(unified(matched[0]))
+			}
+		}
+		return nil
+	}
+	referencesOf := func(t string) []string { return unified(items[resolve(t)]).references() }
+
+	var order []string
+	visited := map[string]bool{}
+	for _, t := range tables {
+		if !visited[t] {
+			visited[t] = true
+			order = append(order, t)
+		}
+	}
+
+	if o.referrers != 0 {
+		refs, err := references(ctx)
+		if err != nil {
+			return nil, fmt.Errorf(`fail to list references: %w`, err)
+		}
+		names := lo.Keys(refs)
+		slices.Sort(names)
+		referrers := map[string][]string{}
+		for _, name := range names {
+			for _, ref := range refs[name] {
+				referrers[ref] = append(referrers[ref], name)
+			}
+		}
+		order, err = follow(order, visited, o.referrers, load, func(t string) []string { return referrers[resolve(t)] })
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if o.references != 0 {
+		var err error
+		order, err = follow(order, visited, o.references, load, referencesOf)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := load(order); err != nil {
+		return nil, err
+	}
+	result := make([]T, 0, len(order))
+	found := map[string]bool{}
+	for _, t := range order {
+		k := resolve(t)
+		item, ok := items[k]
+		if !ok {
+			return nil, fmt.Errorf(`table %s not found`, t)
+		}
+		if !found[k] {
+			found[k] = true
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+// follow appends the tables reachable from the tables in order by next within depth hops, or without limit if depth is negative,
+// where prepare is called with the tables before next is called for them.
+func follow(order []string, visited map[string]bool, depth int, prepare func(tables []string) error, next func(table string) []string) ([]string, error) {
+	frontier := slices.Clone(order)
+	for d := 0; len(frontier) > 0 && (depth < 0 || d < depth); d++ {
+		if err := prepare(frontier); err != nil {
+			return nil, err
+		}
+		var found []string
+		for _, t := range frontier {
+			for _, u := range next(t) {
+				if !visited[u] {
+					visited[u] = true
+					order = append(order, u)
+					found = append(found, u)
+				}
+			}
+		}
+		frontier = found
+	}
+	return order, nil
+}
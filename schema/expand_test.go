@@ -0,0 +1,79 @@
+package schema_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestExpand(t *testing.T) {
+	schemas := map[string]schema.Schema{
+		"Singers":  {Name: "Singers"},
+		"Albums":   {Name: "Albums", Parent: "Singers"},
+		"Songs":    {Name: "Songs", Parent: "Albums", ForeignKeys: []schema.ForeignKey{{Reference: schema.ForeignKeyReference{Table: "Labels"}}}},
+		"Labels":   {Name: "Labels"},
+		"Concerts": {Name: "Concerts", ForeignKeys: []schema.ForeignKey{{Reference: schema.ForeignKeyReference{Table: "Singers"}}}},
+		"a.users":  {Schema: "a", Name: "users"},
+		"b.users":  {Schema: "b", Name: "users", ForeignKeys: []schema.ForeignKey{{Reference: schema.ForeignKeyReference{Schema: "a", Table: "users"}}}},
+		"b.items":  {Schema: "b", Name: "items", Parent: "users"},
+	}
+	testcases := []struct {
+		name   string
+		tables []string
+		opts   []schema.ListOption
+		want   []string
+		// wantListed is the tables listed by list if not nil.
+		wantListed []string
+		wantErr    bool
+	}{
+		{name: "no options", tables: []string{"Songs"}, want: []string{"Songs"}},
+		{name: "references depth 1", tables: []string{"Songs"}, opts: []schema.ListOption{schema.WithReferences(1)}, want: []string{"Songs", "Albums", "Labels"}},
+		{name: "references unlimited", tables: []string{"Songs"}, opts: []schema.ListOption{schema.WithReferences(-1)}, want: []string{"Songs", "Albums", "Labels", "Singers"}},
+		{name: "referrers depth 1", tables: []string{"Singers"}, opts: []schema.ListOption{schema.WithReferrers(1)}, want: []string{"Singers", "Albums", "Concerts"}, wantListed: []string{"Singers", "Albums", "Concerts"}},
+		{name: "referrers and references", tables: []string{"Labels"}, opts: []schema.ListOption{schema.WithReferrers(1), schema.WithReferences(-1)}, want: []string{"Labels", "Songs", "Albums", "Singers"}},
+		{name: "duplicated tables", tables: []string{"Albums", "Albums"}, opts: []schema.ListOption{schema.WithReferences(-1)}, want: []string{"Albums", "Singers"}},
+		{name: "qualified references", tables: []string{"b.items"}, opts: []schema.ListOption{schema.WithReferences(-1)}, want: []string{"b.items", "b.users", "a.users"}},
+		{name: "qualified referrers", tables: []string{"a.users"}, opts: []schema.ListOption{schema.WithReferrers(-1)}, want: []string{"a.users", "b.users", "b.items"}},
+		{name: "missing table", tables: []string{"Unknown"}, opts: []schema.ListOption{schema.WithReferences(-1)}, wantErr: true},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			var listedTables []string
+			got, err := schema.Expand(context.Background(), testcase.tables, testcase.opts,
+				func(ctx context.Context, tables []string) ([]schema.Schema, error) {
+					listedTables = append(listedTables, tables...)
+					var listed []schema.Schema
+					for _, table := range tables {
+						s, ok := schemas[table]
+						if !ok {
+							return nil, fmt.Errorf(`table %s not found`, table)
+						}
+						listed = append(listed, s)
+					}
+					return listed, nil
+				},
+				func(s schema.Schema) schema.Schema { return s },
+				func(ctx context.Context) (map[string][]string, error) {
+					return schema.Schemas(lo.Values(schemas)).References(), nil
+				},
+			)
+			if testcase.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			if testcase.wantListed != nil {
+				require.ElementsMatch(t, testcase.wantListed, listedTables)
+			}
+			require.Equal(t, testcase.want, lo.Map(got, func(s schema.Schema, _ int) string {
+				if s.Schema != "" {
+					return s.Schema + "." + s.Name
+				}
+				return s.Name
+			}))
+		})
+	}
+}
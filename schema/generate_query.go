@@ -0,0 +1,26 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"github.com/Jumpaku/schenerate/files"
+)
+
+type GeneratorWithQuery func(out *files.Writer, rows []Row) error
+
+func GenerateWithQuery(ctx context.Context, q Queryer, stmt string, params []any, generator GeneratorWithQuery) error {
+	rows, err := q.QueryRows(ctx, stmt, params)
+	if err != nil {
+		return fmt.Errorf(`fail to query rows: %w`, err)
+	}
+
+	w := &files.Writer{}
+	if err := generator(w, rows); err != nil {
+		return fmt.Errorf(`fail to process rows: %w`, err)
+	}
+
+	if err := w.SaveAll(); err != nil {
+		return fmt.Errorf(`fail to save files writer: %w`, err)
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"github.com/Jumpaku/schenerate/files"
+)
+
+type GeneratorWithSchema func(out *files.Writer, schemas Schemas) error
+
+func GenerateWithSchema(ctx context.Context, q Queryer, tables []string, generator GeneratorWithSchema) error {
+	schemas, err := q.ListSchemas(ctx, tables)
+	if err != nil {
+		return fmt.Errorf(`fail to list schemas: %w`, err)
+	}
+
+	w := &files.Writer{}
+	if err := generator(w, schemas); err != nil {
+		return err
+	}
+
+	if err := w.SaveAll(); err != nil {
+		return fmt.Errorf(`fail to save files writer: %w`, err)
+	}
+	return nil
+}
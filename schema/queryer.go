@@ -0,0 +1,28 @@
+package schema
+
+import (
+	"context"
+)
+
+// Queryer is the common interface of the queryers of all backends, which allows code to accept any supported database.
+type Queryer interface {
+	// ListTables lists the tables in the database.
+	ListTables(ctx context.Context) ([]Table, error)
+	// ListSchemas lists the schemas of the tables.
+	ListSchemas(ctx context.Context, tables []string) (Schemas, error)
+	// QueryRows executes the query statement with the parameters and returns the rows as maps from column names to values.
+	QueryRows(ctx context.Context, stmt string, params []any) ([]Row, error)
+	// Close closes the connection to the database.
+	Close() error
+}
+
+type Table struct {
+	Catalog string
+	Schema  string
+	Name    string
+	// Type is the type of the table, e.g. "BASE TABLE" and "VIEW" in PostgreSQL and Spanner, and "table" and "view" in SQLite3.
+	Type string
+}
+
+// Row is a row of a query result, which maps column names to values.
+type Row map[string]any
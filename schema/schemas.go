@@ -0,0 +1,138 @@
+package schema
+
+import (
+	"github.com/Jumpaku/schenerate/graph"
+	"github.com/samber/lo"
+)
+
+// Dialect is a database dialect from which schemas are introspected.
+type Dialect string
+
+const (
+	DialectPostgres Dialect = "postgres"
+	DialectSQLite3  Dialect = "sqlite3"
+	DialectSpanner  Dialect = "spanner"
+)
+
+// Schemas is a list of dialect-neutral schemas of tables, which can be converted from the schemas of each backend.
+type Schemas []Schema
+
+// BuildGraph builds a graph whose edges are references by foreign keys and interleaving.
+// References to tables that are not in the schemas are ignored.
+func (s Schemas) BuildGraph() graph.Graph[Schema] {
+	type key struct {
+		schema string
+		table  string
+	}
+	schemaMap := make(map[key]int)
+	for i, schema := range s {
+		schemaMap[key{schema: schema.Schema, table: schema.Name}] = i
+	}
+
+	dep := make([][]int, len(s))
+	for u, schema := range s {
+		d := []int{}
+		if schema.Parent != "" {
+			if v, ok := schemaMap[key{schema: schema.Schema, table: schema.Parent}]; ok {
+				d = append(d, v)
+			}
+		}
+		for _, fk := range schema.ForeignKeys {
+			if v, ok := schemaMap[key{schema: fk.Reference.Schema, table: fk.Reference.Table}]; ok {
+				d = append(d, v)
+			}
+		}
+		dep[u] = lo.Uniq(d)
+	}
+
+	return graph.NewGraph[Schema](s, dep)
+}
+
+// References returns the tables referenced by each table through foreign keys and interleaving,
+// where the tables are identified by the names qualified by their schemas if any.
+func (s Schemas) References() map[string][]string {
+	return lo.SliceToMap(s, func(schema Schema) (string, []string) { return qualifiedName(schema), schema.references() })
+}
+
+// Find returns the schema of the table with the name in the schema namespace.
+func (s Schemas) Find(schema, name string) (Schema, bool) {
+	return lo.Find(s, func(item Schema) bool { return item.Schema == schema && item.Name == name })
+}
+
+type Schema struct {
+	Dialect Dialect `json:"dialect" yaml:"dialect"`
+	// Schema is the namespace of the table, which is empty in SQLite3 and Spanner.
+	Schema string `json:"schema" yaml:"schema"`
+	Name   string `json:"name" yaml:"name"`
+	Type   string `json:"type" yaml:"type"`
+	// Parent is the parent table of an interleaved table in Spanner.
+	Parent     string   `json:"parent" yaml:"parent"`
+	Columns    []Column `json:"columns" yaml:"columns"`
+	PrimaryKey []string `json:"primaryKey" yaml:"primaryKey"`
+	// PrimaryKeyName is the name of the primary key constraint in Postgres, which is empty in the other dialects or if unknown.
+	PrimaryKeyName string       `json:"primaryKeyName,omitempty" yaml:"primaryKeyName,omitempty"`
+	ForeignKeys    []ForeignKey `json:"foreignKeys" yaml:"foreignKeys"`
+	// UniqueKeys is the unique constraints. In SQLite3, they are derived from the indexes created by UNIQUE constraints.
+	UniqueKeys []UniqueKey `json:"uniqueKeys" yaml:"uniqueKeys"`
+	Indexes    []Index     `json:"indexes" yaml:"indexes"`
+}
+
+func (s Schema) references() []string {
+	refs := []string{}
+	if s.Parent != "" {
+		refs = append(refs, qualifiedName(Schema{Schema: s.Schema, Name: s.Parent}))
+	}
+	for _, fk := range s.ForeignKeys {
+		refs = append(refs, qualifiedName(Schema{Schema: fk.Reference.Schema, Name: fk.Reference.Table}))
+	}
+	return lo.Uniq(refs)
+}
+
+// qualifiedName returns the name of the table qualified by its schema if any.
+func qualifiedName(s Schema) string {
+	if s.Schema == "" {
+		return s.Name
+	}
+	return s.Schema + "." + s.Name
+}
+
+// Column returns the column with the name.
+func (s Schema) Column(name string) (Column, bool) {
+	return lo.Find(s.Columns, func(c Column) bool { return c.Name == name })
+}
+
+type Column struct {
+	Name     string `json:"name" yaml:"name"`
+	Type     string `json:"type" yaml:"type"`
+	Nullable bool   `json:"nullable" yaml:"nullable"`
+}
+
+type ForeignKey struct {
+	Name      string              `json:"name" yaml:"name"`
+	Key       []string            `json:"key" yaml:"key"`
+	Reference ForeignKeyReference `json:"reference" yaml:"reference"`
+}
+
+type ForeignKeyReference struct {
+	Schema string   `json:"schema" yaml:"schema"`
+	Table  string   `json:"table" yaml:"table"`
+	Key    []string `json:"key" yaml:"key"`
+}
+
+type UniqueKey struct {
+	Name string   `json:"name" yaml:"name"`
+	Key  []string `json:"key" yaml:"key"`
+}
+
+type Index struct {
+	Name string `json:"name" yaml:"name"`
+	// Origin is how the index was created in SQLite3, which is empty in the other dialects.
+	Origin string         `json:"origin" yaml:"origin"`
+	Unique bool           `json:"unique" yaml:"unique"`
+	Key    []IndexKeyElem `json:"key" yaml:"key"`
+}
+
+type IndexKeyElem struct {
+	Name string `json:"name" yaml:"name"`
+	Desc bool   `json:"desc" yaml:"desc"`
+}
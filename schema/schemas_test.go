@@ -0,0 +1,75 @@
+package schema
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSchemas_BuildGraph(t *testing.T) {
+	testcases := []struct {
+		name string
+		sut  Schemas
+		want [][]int
+	}{
+		{
+			name: "empty",
+			sut:  Schemas{},
+			want: [][]int{},
+		},
+		{
+			name: "foreign keys across schemas",
+			sut: Schemas{
+				{Schema: "a", Name: "T"},
+				{Schema: "b", Name: "T", ForeignKeys: []ForeignKey{{Reference: ForeignKeyReference{Schema: "a", Table: "T"}}}},
+				{Schema: "b", Name: "U", ForeignKeys: []ForeignKey{
+					{Reference: ForeignKeyReference{Schema: "b", Table: "T"}},
+					{Reference: ForeignKeyReference{Schema: "a", Table: "T"}},
+					{Reference: ForeignKeyReference{Schema: "b", Table: "T"}},
+				}},
+			},
+			want: [][]int{{}, {0}, {1, 0}},
+		},
+		{
+			name: "parent",
+			sut: Schemas{
+				{Name: "B_1"},
+				{Name: "B_2", Parent: "B_1"},
+				{Name: "B_3", Parent: "B_2", ForeignKeys: []ForeignKey{{Reference: ForeignKeyReference{Table: "B_1"}}}},
+			},
+			want: [][]int{{}, {0}, {1, 0}},
+		},
+		{
+			name: "missing reference",
+			sut: Schemas{
+				{Name: "A", ForeignKeys: []ForeignKey{{Reference: ForeignKeyReference{Table: "X"}}}},
+				{Name: "B", Parent: "Y"},
+			},
+			want: [][]int{{}, {}},
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.sut.BuildGraph()
+			assert.Equal(t, got.Len(), len(tt.want))
+			for i := 0; i < got.Len(); i++ {
+				assert.ElementsMatch(t, got.References(i), tt.want[i])
+			}
+		})
+	}
+}
+
+func TestSchemas_References(t *testing.T) {
+	sut := Schemas{
+		{Name: "Singers"},
+		{Name: "Albums", Parent: "Singers", ForeignKeys: []ForeignKey{{Reference: ForeignKeyReference{Table: "Singers"}}}},
+		{Schema: "b", Name: "users", ForeignKeys: []ForeignKey{{Reference: ForeignKeyReference{Schema: "a", Table: "users"}}}},
+		{Schema: "b", Name: "items", Parent: "users"},
+	}
+	want := map[string][]string{
+		"Singers": {},
+		"Albums":  {"Singers"},
+		"b.users": {"a.users"},
+		"b.items": {"b.users"},
+	}
+	assert.Equal(t, want, sut.References())
+}
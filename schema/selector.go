@@ -0,0 +1,130 @@
+package schema
+
+import (
+	"fmt"
+	"github.com/samber/lo"
+	"path"
+	"regexp"
+	"strings"
+)
+
+const (
+	TableTypeTable = "table"
+	TableTypeView  = "view"
+)
+
+// Selector selects tables by their names, types and schema names.
+// The zero value selects all the tables except the system tables.
+type Selector struct {
+	// Include is the patterns of the tables to select, which selects all the tables if empty.
+	// A pattern is either a glob of path.Match or a regular expression enclosed in slashes, e.g. "user_*" and "/^users?$/",
+	// which is matched against both the name and the name qualified by the schema name, e.g. "users" and "public.users".
+	Include []string `json:"include" yaml:"include"`
+	// Exclude is the patterns of the tables not to select, which take precedence over Include.
+	Exclude []string `json:"exclude" yaml:"exclude"`
+	// Types is the types of the tables to select, which are "table" and "view", or the types specific to the dialect
+	// such as "virtual" in SQLite3. All the types are selected if empty.
+	Types []string `json:"types" yaml:"types"`
+	// Schemas is the names of the schemas of the tables to select. All the schemas are selected if empty.
+	Schemas []string `json:"schemas" yaml:"schemas"`
+	// System selects also the system tables such as sqlite_schema, sqlite_sequence and the shadow tables in SQLite3.
+	System bool `json:"system" yaml:"system"`
+}
+
+// All returns a Selector selecting all the tables except the system tables.
+func All() Selector {
+	return Selector{}
+}
+
+// Select returns the tables selected from the tables in order.
+func (s Selector) Select(tables []Table) ([]Table, error) {
+	include, err := compilePatterns(s.Include)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to compile include patterns: %w`, err)
+	}
+	exclude, err := compilePatterns(s.Exclude)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to compile exclude patterns: %w`, err)
+	}
+	types := lo.Map(s.Types, func(t string, _ int) string { return normalizeTableType(t) })
+
+	return lo.Filter(tables, func(t Table, _ int) bool {
+		switch {
+		case !s.System && isSystemTable(t):
+			return false
+		case len(types) > 0 && !lo.Contains(types, normalizeTableType(t.Type)):
+			return false
+		case len(s.Schemas) > 0 && !lo.Contains(s.Schemas, t.Schema):
+			return false
+		case len(include) > 0 && !lo.SomeBy(include, func(m matcher) bool { return m.match(t) }):
+			return false
+		case lo.SomeBy(exclude, func(m matcher) bool { return m.match(t) }):
+			return false
+		default:
+			return true
+		}
+	}), nil
+}
+
+// SelectNames returns the distinct names of the tables selected from the tables in order, which can be passed to ListSchemas.
+func (s Selector) SelectNames(tables []Table) ([]string, error) {
+	selected, err := s.Select(tables)
+	if err != nil {
+		return nil, err
+	}
+	return lo.Uniq(lo.Map(selected, func(t Table, _ int) string { return t.Name })), nil
+}
+
+// SelectSchemas returns the schemas selected from the schemas in order, e.g. of the schemas parsed from DDL files.
+func (s Selector) SelectSchemas(schemas Schemas) (Schemas, error) {
+	tables := lo.Map(schemas, func(s Schema, _ int) Table { return Table{Schema: s.Schema, Name: s.Name, Type: s.Type} })
+	selected, err := s.Select(tables)
+	if err != nil {
+		return nil, err
+	}
+	return lo.Filter(schemas, func(s Schema, _ int) bool {
+		return lo.ContainsBy(selected, func(t Table) bool { return t.Schema == s.Schema && t.Name == s.Name })
+	}), nil
+}
+
+type matcher func(s string) bool
+
+func (m matcher) match(t Table) bool {
+	return m(t.Name) || (t.Schema != "" && m(t.Schema+"."+t.Name))
+}
+
+func compilePatterns(patterns []string) ([]matcher, error) {
+	var matchers []matcher
+	for _, p := range patterns {
+		if len(p) >= 2 && strings.HasPrefix(p, "/") && strings.HasSuffix(p, "/") {
+			re, err := regexp.Compile(p[1 : len(p)-1])
+			if err != nil {
+				return nil, fmt.Errorf(`invalid regular expression %q: %w`, p, err)
+			}
+			matchers = append(matchers, re.MatchString)
+			continue
+		}
+		p := p
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf(`invalid glob %q: %w`, p, err)
+		}
+		matchers = append(matchers, func(s string) bool {
+			ok, _ := path.Match(p, s)
+			return ok
+		})
+	}
+	return matchers, nil
+}
+
+// normalizeTableType converts "BASE TABLE" in PostgreSQL and Spanner into "table" and the others into lower case.
+func normalizeTableType(t string) string {
+	t = strings.ToLower(t)
+	if t == "base table" {
+		return TableTypeTable
+	}
+	return t
+}
+
+func isSystemTable(t Table) bool {
+	return strings.HasPrefix(t.Name, "sqlite_") || normalizeTableType(t.Type) == "shadow"
+}
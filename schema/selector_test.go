@@ -0,0 +1,107 @@
+package schema_test
+
+import (
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSelector_Select(t *testing.T) {
+	tables := []schema.Table{
+		{Schema: "main", Name: "users", Type: "table"},
+		{Schema: "main", Name: "user_items", Type: "table"},
+		{Schema: "main", Name: "user_view", Type: "view"},
+		{Schema: "main", Name: "docs", Type: "virtual"},
+		{Schema: "main", Name: "docs_data", Type: "shadow"},
+		{Schema: "main", Name: "sqlite_sequence", Type: "table"},
+		{Schema: "main", Name: "sqlite_schema", Type: "table"},
+		{Schema: "temp", Name: "sqlite_temp_schema", Type: "table"},
+		{Schema: "public", Name: "items", Type: "BASE TABLE"},
+		{Schema: "audit", Name: "items", Type: "VIEW"},
+	}
+	testcases := []struct {
+		name     string
+		selector schema.Selector
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "all",
+			selector: schema.All(),
+			want:     []string{"main.users", "main.user_items", "main.user_view", "main.docs", "public.items", "audit.items"},
+		},
+		{
+			name:     "system",
+			selector: schema.Selector{System: true, Schemas: []string{"main", "temp"}},
+			want:     []string{"main.users", "main.user_items", "main.user_view", "main.docs", "main.docs_data", "main.sqlite_sequence", "main.sqlite_schema", "temp.sqlite_temp_schema"},
+		},
+		{
+			name:     "include glob",
+			selector: schema.Selector{Include: []string{"user*"}},
+			want:     []string{"main.users", "main.user_items", "main.user_view"},
+		},
+		{
+			name:     "include qualified glob",
+			selector: schema.Selector{Include: []string{"public.*"}},
+			want:     []string{"public.items"},
+		},
+		{
+			name:     "include regexp and exclude",
+			selector: schema.Selector{Include: []string{`/^user/`, "docs"}, Exclude: []string{"*_view", `/_items$/`}},
+			want:     []string{"main.users", "main.docs"},
+		},
+		{
+			name:     "types",
+			selector: schema.Selector{Types: []string{"TABLE"}},
+			want:     []string{"main.users", "main.user_items", "public.items"},
+		},
+		{
+			name:     "views",
+			selector: schema.Selector{Types: []string{"view"}},
+			want:     []string{"main.user_view", "audit.items"},
+		},
+		{
+			name:     "schemas",
+			selector: schema.Selector{Schemas: []string{"audit"}},
+			want:     []string{"audit.items"},
+		},
+		{name: "invalid regexp", selector: schema.Selector{Include: []string{`/(/`}}, wantErr: true},
+		{name: "invalid glob", selector: schema.Selector{Exclude: []string{`[`}}, wantErr: true},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			got, err := testcase.selector.Select(tables)
+			if testcase.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			var names []string
+			for _, t := range got {
+				names = append(names, t.Schema+"."+t.Name)
+			}
+			require.Equal(t, testcase.want, names)
+		})
+	}
+}
+
+func TestSelector_SelectNames(t *testing.T) {
+	got, err := schema.All().SelectNames([]schema.Table{
+		{Schema: "public", Name: "items", Type: "BASE TABLE"},
+		{Schema: "audit", Name: "items", Type: "BASE TABLE"},
+		{Schema: "public", Name: "users", Type: "BASE TABLE"},
+	})
+	require.Nil(t, err)
+	require.Equal(t, []string{"items", "users"}, got)
+}
+
+func TestSelector_SelectSchemas(t *testing.T) {
+	schemas := schema.Schemas{
+		{Dialect: schema.DialectSpanner, Name: "Users", Type: "BASE TABLE"},
+		{Dialect: schema.DialectSpanner, Name: "UserItems", Type: "BASE TABLE"},
+		{Dialect: schema.DialectSpanner, Name: "UsersView", Type: "VIEW"},
+	}
+	got, err := schema.Selector{Include: []string{"User*"}, Types: []string{"table"}}.SelectSchemas(schemas)
+	require.Nil(t, err)
+	require.Equal(t, schemas[:2], got)
+}
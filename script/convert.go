@@ -0,0 +1,107 @@
+package script
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/Jumpaku/schenerate/schema"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"slices"
+)
+
+// toStarlark converts v into Starlark values via JSON, where objects are converted into structs.
+func toStarlark(v any) (starlark.Value, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	var j any
+	if err := d.Decode(&j); err != nil {
+		return nil, err
+	}
+	return fromJSON(j)
+}
+
+func fromJSON(j any) (starlark.Value, error) {
+	switch j := j.(type) {
+	case nil:
+		return starlark.None, nil
+	case bool:
+		return starlark.Bool(j), nil
+	case string:
+		return starlark.String(j), nil
+	case json.Number:
+		if i, err := j.Int64(); err == nil {
+			return starlark.MakeInt64(i), nil
+		}
+		f, err := j.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return starlark.Float(f), nil
+	case []any:
+		elems := make([]starlark.Value, len(j))
+		for i, e := range j {
+			v, err := fromJSON(e)
+			if err != nil {
+				return nil, err
+			}
+			elems[i] = v
+		}
+		return starlark.NewList(elems), nil
+	case map[string]any:
+		fields := starlark.StringDict{}
+		for k, e := range j {
+			v, err := fromJSON(e)
+			if err != nil {
+				return nil, err
+			}
+			fields[k] = v
+		}
+		return starlarkstruct.FromStringDict(starlarkstruct.Default, fields), nil
+	default:
+		return nil, fmt.Errorf(`unexpected JSON value %T`, j)
+	}
+}
+
+func newGraph(schemas schema.Schemas) starlark.Value {
+	g := schemas.BuildGraph()
+	ints := func(is []int) *starlark.List {
+		elems := make([]starlark.Value, len(is))
+		for i, v := range is {
+			elems[i] = starlark.MakeInt(v)
+		}
+		return starlark.NewList(elems)
+	}
+	return starlarkstruct.FromStringDict(starlark.String("graph"), starlark.StringDict{
+		"len": starlark.NewBuiltin("len", func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 0); err != nil {
+				return nil, err
+			}
+			return starlark.MakeInt(g.Len()), nil
+		}),
+		"references": starlark.NewBuiltin("references", func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var i int
+			if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 1, &i); err != nil {
+				return nil, err
+			}
+			if i < 0 || i >= g.Len() {
+				return nil, fmt.Errorf(`%s: index %d out of range [0, %d)`, b.Name(), i, g.Len())
+			}
+			return ints(slices.Clone(g.References(i))), nil
+		}),
+		"topological_sort": starlark.NewBuiltin("topological_sort", func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 0); err != nil {
+				return nil, err
+			}
+			order, cyclic := g.TopologicalSort()
+			if cyclic {
+				return starlark.Tuple{starlark.None, starlark.True}, nil
+			}
+			return starlark.Tuple{ints(order), starlark.False}, nil
+		}),
+	})
+}
@@ -0,0 +1,138 @@
+package script
+
+import (
+	"fmt"
+	"github.com/Jumpaku/schenerate/name"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"hash/fnv"
+)
+
+// nameValue is a name.Name in Starlark, which keeps the words of the name across plural, singular, append and prepend.
+type nameValue struct {
+	n name.Name
+}
+
+var _ starlark.Value = nameValue{}
+
+func (v nameValue) String() string       { return v.n.String() }
+func (v nameValue) Type() string         { return "name" }
+func (v nameValue) Freeze()              {}
+func (v nameValue) Truth() starlark.Bool { return v.n.Len() > 0 }
+func (v nameValue) Hash() (uint32, error) {
+	h := fnv.New32a()
+	h.Write([]byte(v.n.String()))
+	return h.Sum32(), nil
+}
+
+func toName(b *starlark.Builtin, v starlark.Value) (name.Name, error) {
+	switch v := v.(type) {
+	case nameValue:
+		return v.n, nil
+	case starlark.String:
+		return name.New(string(v)), nil
+	default:
+		return name.Name{}, fmt.Errorf(`%s: string or name expected but got %s`, b.Name(), v.Type())
+	}
+}
+
+func nameFunc(f func(b *starlark.Builtin, n name.Name, args starlark.Tuple) (starlark.Value, error), nArgs int) func(*starlark.Thread, *starlark.Builtin, starlark.Tuple, []starlark.Tuple) (starlark.Value, error) {
+	return func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		if len(kwargs) > 0 {
+			return nil, fmt.Errorf(`%s: unexpected keyword arguments`, b.Name())
+		}
+		if len(args) != nArgs+1 {
+			return nil, fmt.Errorf(`%s: got %d arguments, want %d`, b.Name(), len(args), nArgs+1)
+		}
+		n, err := toName(b, args[0])
+		if err != nil {
+			return nil, err
+		}
+		return f(b, n, args[1:])
+	}
+}
+
+func conv(f func(n name.Name) string) func(*starlark.Thread, *starlark.Builtin, starlark.Tuple, []starlark.Tuple) (starlark.Value, error) {
+	return nameFunc(func(_ *starlark.Builtin, n name.Name, _ starlark.Tuple) (starlark.Value, error) {
+		return starlark.String(f(n)), nil
+	}, 0)
+}
+
+func inflect(f func(n name.Name) name.Name) func(*starlark.Thread, *starlark.Builtin, starlark.Tuple, []starlark.Tuple) (starlark.Value, error) {
+	return nameFunc(func(_ *starlark.Builtin, n name.Name, _ starlark.Tuple) (starlark.Value, error) {
+		return nameValue{f(n)}, nil
+	}, 0)
+}
+
+func stringArgs(b *starlark.Builtin, args starlark.Tuple) ([]string, error) {
+	ss := make([]string, len(args))
+	for i, arg := range args {
+		s, ok := starlark.AsString(arg)
+		if !ok {
+			return nil, fmt.Errorf(`%s: string expected but got %s`, b.Name(), arg.Type())
+		}
+		ss[i] = s
+	}
+	return ss, nil
+}
+
+// nameModule provides the functions of name.FuncMap, where the functions take the name as the first argument,
+// e.g. name.append(n, "id") and name.shorten(n, 63).
+var nameModule = &starlarkstruct.Module{
+	Name: "name",
+	Members: starlark.StringDict{
+		"new": starlark.NewBuiltin("new", nameFunc(func(_ *starlark.Builtin, n name.Name, _ starlark.Tuple) (starlark.Value, error) {
+			return nameValue{n}, nil
+		}, 0)),
+		"upper_camel":       starlark.NewBuiltin("upper_camel", conv(name.Name.UpperCamel)),
+		"pascal":            starlark.NewBuiltin("pascal", conv(name.Name.UpperCamel)),
+		"lower_camel":       starlark.NewBuiltin("lower_camel", conv(name.Name.LowerCamel)),
+		"camel":             starlark.NewBuiltin("camel", conv(name.Name.LowerCamel)),
+		"lower_snake":       starlark.NewBuiltin("lower_snake", conv(name.Name.LowerSnake)),
+		"snake":             starlark.NewBuiltin("snake", conv(name.Name.LowerSnake)),
+		"all_upper_snake":   starlark.NewBuiltin("all_upper_snake", conv(name.Name.AllUpperSnake)),
+		"screaming_snake":   starlark.NewBuiltin("screaming_snake", conv(name.Name.AllUpperSnake)),
+		"first_upper_snake": starlark.NewBuiltin("first_upper_snake", conv(name.Name.FirstUpperSnake)),
+		"lower_kebab":       starlark.NewBuiltin("lower_kebab", conv(name.Name.LowerKebab)),
+		"kebab":             starlark.NewBuiltin("kebab", conv(name.Name.LowerKebab)),
+		"all_upper_kebab":   starlark.NewBuiltin("all_upper_kebab", conv(name.Name.AllUpperKebab)),
+		"first_upper_kebab": starlark.NewBuiltin("first_upper_kebab", conv(name.Name.FirstUpperKebab)),
+		"plural":            starlark.NewBuiltin("plural", inflect(name.Name.Plural)),
+		"singular":          starlark.NewBuiltin("singular", inflect(name.Name.Singular)),
+		"append": starlark.NewBuiltin("append", nameFunc(func(b *starlark.Builtin, n name.Name, args starlark.Tuple) (starlark.Value, error) {
+			ss, err := stringArgs(b, args)
+			if err != nil {
+				return nil, err
+			}
+			return nameValue{n.Append(ss[0])}, nil
+		}, 1)),
+		"prepend": starlark.NewBuiltin("prepend", nameFunc(func(b *starlark.Builtin, n name.Name, args starlark.Tuple) (starlark.Value, error) {
+			ss, err := stringArgs(b, args)
+			if err != nil {
+				return nil, err
+			}
+			return nameValue{n.Prepend(ss[0])}, nil
+		}, 1)),
+		"join_words": starlark.NewBuiltin("join_words", nameFunc(func(b *starlark.Builtin, n name.Name, args starlark.Tuple) (starlark.Value, error) {
+			ss, err := stringArgs(b, args)
+			if err != nil {
+				return nil, err
+			}
+			return starlark.String(n.Join(ss[0], ss[1], ss[2])), nil
+		}, 3)),
+		"shorten": starlark.NewBuiltin("shorten", nameFunc(func(b *starlark.Builtin, n name.Name, args starlark.Tuple) (starlark.Value, error) {
+			maxLen, err := starlark.AsInt32(args[0])
+			if err != nil {
+				return nil, fmt.Errorf(`%s: %w`, b.Name(), err)
+			}
+			return starlark.String(name.Shorten(n.String(), maxLen)), nil
+		}, 1)),
+		"quote": starlark.NewBuiltin("quote", nameFunc(func(b *starlark.Builtin, n name.Name, args starlark.Tuple) (starlark.Value, error) {
+			ss, err := stringArgs(b, args)
+			if err != nil {
+				return nil, err
+			}
+			return starlark.String(name.Quote(name.Dialect(ss[0]), n.String())), nil
+		}, 1)),
+	},
+}
@@ -0,0 +1,184 @@
+// Package script generates files by Starlark scripts, which suit small generators that do not justify Go programs.
+//
+// A script is executed once with the following predeclared names, in addition to the built-ins of Starlark:
+//
+//   - schemas: the list of the schemas, whose fields are the JSON fields of schema.Schema, e.g. schemas[0].primaryKey.
+//   - graph: the graph of references among the schemas by their indexes as graph.Graph, which has len,
+//     references(i) and topological_sort() returning a tuple of the order, which is None if cyclic, and cyclic.
+//   - params: the dict of the parameters given by WithParameters.
+//   - name: the module of the functions of name.FuncMap in snake case, e.g. name.pascal("user_items"), name.plural(s).
+//   - out: the writer of the files, where out.add(path) starts a file at the path relative to the output directory
+//     and out.write(s, ...) appends the strings to the file.
+//
+// For example:
+//
+//	for s in schemas:
+//	    out.add(name.snake(s.name) + ".txt")
+//	    out.write(name.pascal(name.singular(s.name)), "\n")
+//	    for c in s.columns:
+//	        out.write("  ", c.name, " ", c.type, "\n")
+package script
+
+import (
+	"context"
+	"fmt"
+	"github.com/Jumpaku/schenerate/files"
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/samber/lo"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"go.starlark.net/syntax"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// DefaultMaxSteps is the default maximum number of the execution steps of a script.
+const DefaultMaxSteps = 100_000_000
+
+// Generator executes a Starlark script to generate files.
+type Generator struct {
+	ctx      context.Context
+	path     string
+	program  *starlark.Program
+	outDir   string
+	params   map[string]string
+	stdout   io.Writer
+	maxSteps uint64
+}
+
+// Option configures a Generator.
+type Option func(*Generator)
+
+// WithParameters sets the parameters available as params in the script.
+func WithParameters(params map[string]string) Option {
+	return func(g *Generator) { g.params = params }
+}
+
+// WithPrint sets the writer of the outputs of print in the script, which are discarded by default.
+func WithPrint(w io.Writer) Option {
+	return func(g *Generator) { g.stdout = w }
+}
+
+// WithMaxSteps sets the maximum number of the execution steps of the script, which is DefaultMaxSteps by default.
+func WithMaxSteps(n uint64) Option {
+	return func(g *Generator) { g.maxSteps = n }
+}
+
+var fileOptions = &syntax.FileOptions{Set: true, While: true, TopLevelControl: true, GlobalReassign: true, Recursion: true}
+
+var predeclared = []string{"schemas", "graph", "params", "name", "out"}
+
+// Load compiles the script at path, whose outputs are written under outDir.
+// The script cannot load other modules, and its executions are cancelled when ctx is done.
+func Load(ctx context.Context, path, outDir string, opts ...Option) (*Generator, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to read script %s: %w`, path, err)
+	}
+	_, program, err := starlark.SourceProgramOptions(fileOptions, path, src, func(name string) bool {
+		return lo.Contains(predeclared, name)
+	})
+	if err != nil {
+		return nil, fmt.Errorf(`fail to compile script %s: %w`, path, err)
+	}
+
+	g := &Generator{ctx: ctx, path: path, program: program, outDir: outDir, params: map[string]string{}, stdout: io.Discard, maxSteps: DefaultMaxSteps}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g, nil
+}
+
+// Generate executes the script with the schemas, which can be passed to schema.GenerateWithSchema.
+// It fails if the script exceeds the maximum number of the execution steps or the context of Load is done.
+func (g *Generator) Generate(out *files.Writer, schemas schema.Schemas) error {
+	if err := g.ctx.Err(); err != nil {
+		return fmt.Errorf(`fail to execute script %s: %w`, g.path, err)
+	}
+	values, err := toStarlark(schemas)
+	if err != nil {
+		return fmt.Errorf(`fail to convert schemas: %w`, err)
+	}
+	params := starlark.NewDict(len(g.params))
+	for k, v := range g.params {
+		if err := params.SetKey(starlark.String(k), starlark.String(v)); err != nil {
+			return fmt.Errorf(`fail to convert parameters: %w`, err)
+		}
+	}
+	w := &writer{out: out, outDir: g.outDir}
+
+	thread := &starlark.Thread{
+		Name:  g.path,
+		Print: func(_ *starlark.Thread, msg string) { fmt.Fprintln(g.stdout, msg) },
+		Load: func(_ *starlark.Thread, module string) (starlark.StringDict, error) {
+			return nil, fmt.Errorf(`load is not supported: %s`, module)
+		},
+	}
+	thread.SetMaxExecutionSteps(g.maxSteps)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-g.ctx.Done():
+			thread.Cancel(g.ctx.Err().Error())
+		case <-done:
+		}
+	}()
+	_, err = g.program.Init(thread, starlark.StringDict{
+		"schemas": values,
+		"graph":   newGraph(schemas),
+		"params":  params,
+		"name":    nameModule,
+		"out": starlarkstruct.FromStringDict(starlark.String("writer"), starlark.StringDict{
+			"add":   starlark.NewBuiltin("add", w.add),
+			"write": starlark.NewBuiltin("write", w.write),
+		}),
+	})
+	if err != nil {
+		if evalErr, ok := err.(*starlark.EvalError); ok {
+			return fmt.Errorf(`fail to execute script %s: %s`, g.path, evalErr.Backtrace())
+		}
+		return fmt.Errorf(`fail to execute script %s: %w`, g.path, err)
+	}
+	return nil
+}
+
+type writer struct {
+	out    *files.Writer
+	outDir string
+	added  bool
+}
+
+func (w *writer) add(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var p string
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 1, &p); err != nil {
+		return nil, err
+	}
+	path := filepath.FromSlash(p)
+	if !filepath.IsLocal(path) {
+		return nil, fmt.Errorf(`%s: path %q is outside of the output directory`, b.Name(), p)
+	}
+	w.out.Add(filepath.Join(w.outDir, path))
+	w.added = true
+	return starlark.None, nil
+}
+
+func (w *writer) write(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if len(kwargs) > 0 {
+		return nil, fmt.Errorf(`%s: unexpected keyword arguments`, b.Name())
+	}
+	if !w.added {
+		return nil, fmt.Errorf(`%s: no file is added`, b.Name())
+	}
+	for _, arg := range args {
+		s, ok := starlark.AsString(arg)
+		if !ok {
+			s = arg.String()
+		}
+		if _, err := io.WriteString(w.out, s); err != nil {
+			return nil, err
+		}
+	}
+	return starlark.None, nil
+}
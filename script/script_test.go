@@ -0,0 +1,126 @@
+package script_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/Jumpaku/schenerate/files"
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/Jumpaku/schenerate/script"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGenerator_Generate(t *testing.T) {
+	schemas := schema.Schemas{
+		{Dialect: schema.DialectSQLite3, Name: "user_items", Columns: []schema.Column{{Name: "id", Type: "INTEGER"}, {Name: "user_id", Type: "INTEGER", Nullable: true}},
+			ForeignKeys: []schema.ForeignKey{{Key: []string{"user_id"}, Reference: schema.ForeignKeyReference{Table: "users", Key: []string{"id"}}}}},
+		{Dialect: schema.DialectSQLite3, Name: "users", Columns: []schema.Column{{Name: "id", Type: "INTEGER"}}},
+	}
+	testcases := []struct {
+		name      string
+		src       string
+		params    map[string]string
+		want      map[string]string
+		wantPrint string
+		wantErr   bool
+	}{
+		{
+			name: "files per schema",
+			src: `
+for s in schemas:
+    out.add("models/" + name.snake(name.singular(s.name)) + ".go")
+    out.write("type ", name.pascal(name.singular(s.name)), " struct {")
+    for c in s.columns:
+        out.write(" ", name.pascal(c.name), "(", c.type, ",", c.nullable, ")")
+    out.write(" }")
+`,
+			want: map[string]string{
+				"models/user_item.go": `type UserItem struct { Id(INTEGER,False) UserId(INTEGER,True) }`,
+				"models/user.go":      `type User struct { Id(INTEGER,False) }`,
+			},
+		},
+		{
+			name: "graph and params",
+			src: `
+order, cyclic = graph.topological_sort()
+out.add(params["file"])
+out.write(",".join([schemas[i].name for i in order]), " cyclic=", cyclic, " refs=", graph.references(0), " len=", graph.len())
+print(schemas[0].foreignKeys[0].reference.table)
+`,
+			params:    map[string]string{"file": "order.txt"},
+			want:      map[string]string{"order.txt": `users,user_items cyclic=False refs=[1] len=2`},
+			wantPrint: "users\n",
+		},
+		{
+			name: "name functions",
+			src: `
+out.add("names.txt")
+n = name.append(name.new("user"), "id")
+out.write(name.screaming_snake(n), " ", name.kebab(name.plural("UserItem")), " ", name.join_words(n, ".", "<", ">"), " ", name.shorten("users", 5), " ", name.quote("users", "postgres"))
+`,
+			want: map[string]string{"names.txt": `USER_ID user-items <user.id> users "users"`},
+		},
+		{name: "path outside of output directory", src: `out.add("../x.txt")`, wantErr: true},
+		{name: "write without add", src: `out.write("x")`, wantErr: true},
+		{name: "load", src: `load("x.star", "y")`, wantErr: true},
+		{name: "runtime error", src: `fail("something wrong")`, wantErr: true},
+		{name: "infinite loop", src: "while True:\n    pass\n", wantErr: true},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "gen.star")
+			require.Nil(t, os.WriteFile(path, []byte(testcase.src), 0644))
+			outDir := filepath.Join(dir, "out")
+			var print bytes.Buffer
+
+			g, err := script.Load(context.Background(), path, outDir, script.WithParameters(testcase.params), script.WithPrint(&print), script.WithMaxSteps(100_000))
+			if err != nil {
+				require.True(t, testcase.wantErr, err)
+				return
+			}
+			w := &files.Writer{}
+			err = g.Generate(w, schemas)
+			if testcase.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Nil(t, w.SaveAll())
+			for name, want := range testcase.want {
+				got, err := os.ReadFile(filepath.Join(outDir, filepath.FromSlash(name)))
+				require.Nil(t, err)
+				require.Equal(t, want, string(got))
+			}
+			require.Equal(t, testcase.wantPrint, print.String())
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "gen.star")
+	require.Nil(t, os.WriteFile(path, []byte(`out.add(`), 0644))
+
+	_, err := script.Load(context.Background(), path, dir)
+	require.NotNil(t, err)
+
+	_, err = script.Load(context.Background(), filepath.Join(dir, "unknown.star"), dir)
+	require.NotNil(t, err)
+}
+
+func TestGenerator_Generate_cancel(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "gen.star")
+	require.Nil(t, os.WriteFile(path, []byte("while True:\n    pass\n"), 0644))
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	g, err := script.Load(ctx, path, dir)
+	require.Nil(t, err)
+	err = g.Generate(&files.Writer{}, nil)
+	require.ErrorContains(t, err, context.DeadlineExceeded.Error())
+}
@@ -0,0 +1,127 @@
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Version is the version of the snapshot format, which is incremented on incompatible changes.
+const Version = 1
+
+// Format is a serialization format of snapshots.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+// FormatOf returns the format determined by the extension of the path, which is .json, .yaml or .yml.
+func FormatOf(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON, nil
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	default:
+		return "", fmt.Errorf(`unknown snapshot format of %q: .json, .yaml or .yml expected`, path)
+	}
+}
+
+// Snapshot is the envelope of schemas, which records the version of the format and the dialect of the schemas.
+type Snapshot[T any] struct {
+	Version int    `json:"version" yaml:"version"`
+	Dialect string `json:"dialect" yaml:"dialect"`
+	Schemas T      `json:"schemas" yaml:"schemas"`
+}
+
+// Marshal serializes the schemas of the dialect into a snapshot in the format.
+func Marshal[T any](format Format, dialect string, schemas T) ([]byte, error) {
+	s := Snapshot[T]{Version: Version, Dialect: dialect, Schemas: schemas}
+	var b bytes.Buffer
+	switch format {
+	case FormatJSON:
+		e := json.NewEncoder(&b)
+		e.SetIndent("", "  ")
+		if err := e.Encode(s); err != nil {
+			return nil, fmt.Errorf(`fail to marshal snapshot to JSON: %w`, err)
+		}
+	case FormatYAML:
+		e := yaml.NewEncoder(&b)
+		e.SetIndent(2)
+		if err := e.Encode(s); err != nil {
+			return nil, fmt.Errorf(`fail to marshal snapshot to YAML: %w`, err)
+		}
+		if err := e.Close(); err != nil {
+			return nil, fmt.Errorf(`fail to marshal snapshot to YAML: %w`, err)
+		}
+	default:
+		return nil, fmt.Errorf(`unknown snapshot format %q`, format)
+	}
+	return b.Bytes(), nil
+}
+
+// Unmarshal deserializes the schemas from a snapshot in the format, which must be of the dialect and the current version.
+func Unmarshal[T any](format Format, dialect string, b []byte) (T, error) {
+	var zero T
+	var s Snapshot[T]
+	switch format {
+	case FormatJSON:
+		if err := json.Unmarshal(b, &s); err != nil {
+			return zero, fmt.Errorf(`fail to unmarshal snapshot from JSON: %w`, err)
+		}
+	case FormatYAML:
+		if err := yaml.Unmarshal(b, &s); err != nil {
+			return zero, fmt.Errorf(`fail to unmarshal snapshot from YAML: %w`, err)
+		}
+	default:
+		return zero, fmt.Errorf(`unknown snapshot format %q`, format)
+	}
+
+	if s.Version != Version {
+		return zero, fmt.Errorf(`unsupported snapshot version %d: %d expected`, s.Version, Version)
+	}
+	if s.Dialect != dialect {
+		return zero, fmt.Errorf(`snapshot of dialect %q: %q expected`, s.Dialect, dialect)
+	}
+	return s.Schemas, nil
+}
+
+// Save writes the schemas of the dialect to a snapshot file in the format determined by the extension of the path.
+func Save[T any](path, dialect string, schemas T) error {
+	format, err := FormatOf(path)
+	if err != nil {
+		return err
+	}
+	b, err := Marshal(format, dialect, schemas)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, b, 0644); err != nil {
+		return fmt.Errorf(`fail to write snapshot file %s: %w`, path, err)
+	}
+	return nil
+}
+
+// Load reads the schemas of the dialect from a snapshot file in the format determined by the extension of the path.
+func Load[T any](path, dialect string) (T, error) {
+	var zero T
+	format, err := FormatOf(path)
+	if err != nil {
+		return zero, err
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return zero, fmt.Errorf(`fail to read snapshot file %s: %w`, path, err)
+	}
+	schemas, err := Unmarshal[T](format, dialect, b)
+	if err != nil {
+		return zero, fmt.Errorf(`fail to load snapshot file %s: %w`, path, err)
+	}
+	return schemas, nil
+}
@@ -0,0 +1,103 @@
+package snapshot_test
+
+import (
+	"github.com/Jumpaku/schenerate/snapshot"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+)
+
+type table struct {
+	Name    string   `json:"name" yaml:"name"`
+	Columns []string `json:"columns" yaml:"columns"`
+}
+
+func TestMarshal(t *testing.T) {
+	in := []table{{Name: "A", Columns: []string{"x", "y"}}, {Name: "B", Columns: []string{}}}
+	testcases := []struct {
+		format snapshot.Format
+		want   string
+	}{
+		{
+			format: snapshot.FormatJSON,
+			want: `{
+  "version": 1,
+  "dialect": "test",
+  "schemas": [
+    {
+      "name": "A",
+      "columns": [
+        "x",
+        "y"
+      ]
+    },
+    {
+      "name": "B",
+      "columns": []
+    }
+  ]
+}
+`,
+		},
+		{
+			format: snapshot.FormatYAML,
+			want: `version: 1
+dialect: test
+schemas:
+  - name: A
+    columns:
+      - x
+      - "y"
+  - name: B
+    columns: []
+`,
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(string(testcase.format), func(t *testing.T) {
+			got, err := snapshot.Marshal(testcase.format, "test", in)
+			require.Nil(t, err)
+			require.Equal(t, testcase.want, string(got))
+
+			back, err := snapshot.Unmarshal[[]table](testcase.format, "test", got)
+			require.Nil(t, err)
+			require.Equal(t, in, back)
+		})
+	}
+}
+
+func TestUnmarshal_Error(t *testing.T) {
+	testcases := []struct {
+		name   string
+		format snapshot.Format
+		in     string
+	}{
+		{name: "version", format: snapshot.FormatJSON, in: `{"version":2,"dialect":"test","schemas":[]}`},
+		{name: "dialect", format: snapshot.FormatYAML, in: "version: 1\ndialect: other\nschemas: []\n"},
+		{name: "syntax", format: snapshot.FormatJSON, in: `{`},
+		{name: "format", format: "xml", in: `<schemas/>`},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			_, err := snapshot.Unmarshal[[]table](testcase.format, "test", []byte(testcase.in))
+			require.NotNil(t, err)
+		})
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	in := []table{{Name: "A", Columns: []string{"x"}}}
+	for _, file := range []string{"snapshot.json", "snapshot.yaml", "snapshot.yml"} {
+		t.Run(file, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), file)
+			require.Nil(t, snapshot.Save(path, "test", in))
+
+			got, err := snapshot.Load[[]table](path, "test")
+			require.Nil(t, err)
+			require.Equal(t, in, got)
+		})
+	}
+	t.Run("unknown extension", func(t *testing.T) {
+		require.NotNil(t, snapshot.Save(filepath.Join(t.TempDir(), "snapshot.txt"), "test", in))
+	})
+}
@@ -0,0 +1,141 @@
+package spanner
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ColumnType is a parsed representation of a column type such as STRING(50), BYTES(MAX) and ARRAY<INT64>.
+type ColumnType struct {
+	// Base is the type name without parameters in upper case, e.g. STRING, INT64 and ARRAY.
+	Base string
+	// Length is the maximum length of STRING or BYTES, which is 0 if Max is true.
+	Length int64
+	// Max is true if the length of STRING or BYTES is MAX.
+	Max bool
+	// Precision and Scale are the parameters of the other types, e.g. NUMERIC(10, 2) in the PostgreSQL interface,
+	// which are 0 if not specified. Spanner GoogleSQL itself has no types with them.
+	Precision int64
+	Scale     int64
+	// Array is true if the type is ARRAY, whose element type is Elem.
+	Array bool
+	Elem  *ColumnType
+	// Proto is the fully qualified name of the PROTO or ENUM type.
+	Proto string
+}
+
+// ParseType parses the type of the column.
+func (c Column) ParseType() (ColumnType, error) {
+	return ParseColumnType(c.Type)
+}
+
+// ParseColumnType parses a column type of Spanner GoogleSQL.
+func ParseColumnType(s string) (ColumnType, error) {
+	t, err := parseColumnType(strings.TrimSpace(s))
+	if err != nil {
+		return ColumnType{}, fmt.Errorf(`fail to parse column type %q: %w`, s, err)
+	}
+	return t, nil
+}
+
+func parseColumnType(s string) (ColumnType, error) {
+	if s == "" {
+		return ColumnType{}, fmt.Errorf(`empty type`)
+	}
+
+	if base, rest, ok := strings.Cut(s, "<"); ok {
+		base = strings.ToUpper(strings.TrimSpace(base))
+		end := closingAngle(rest)
+		if end < 0 {
+			return ColumnType{}, fmt.Errorf(`unclosed '<'`)
+		}
+		inner := strings.TrimSpace(rest[:end])
+		switch base {
+		case "ARRAY":
+			// Parameters after the element type such as (vector_length=>128) are ignored.
+			elem, err := parseColumnType(inner)
+			if err != nil {
+				return ColumnType{}, err
+			}
+			return ColumnType{Base: base, Array: true, Elem: &elem}, nil
+		case "PROTO", "ENUM":
+			if inner == "" {
+				return ColumnType{}, fmt.Errorf(`empty %s type name`, base)
+			}
+			return ColumnType{Base: base, Proto: inner}, nil
+		default:
+			return ColumnType{}, fmt.Errorf(`unexpected '<' after %s`, base)
+		}
+	}
+
+	base, rest, hasParams := strings.Cut(s, "(")
+	t := ColumnType{Base: strings.ToUpper(strings.TrimSpace(base))}
+	if !isIdent(t.Base) {
+		return ColumnType{}, fmt.Errorf(`invalid type name %q`, base)
+	}
+	if !hasParams {
+		return t, nil
+	}
+	param, ok := strings.CutSuffix(strings.TrimSpace(rest), ")")
+	if !ok {
+		return ColumnType{}, fmt.Errorf(`unclosed '('`)
+	}
+	param = strings.TrimSpace(param)
+	if strings.EqualFold(param, "MAX") {
+		t.Max = true
+		return t, nil
+	}
+	var params []int64
+	for _, p := range strings.Split(param, ",") {
+		v, err := strconv.ParseInt(strings.TrimSpace(p), 10, 64)
+		if err != nil {
+			return ColumnType{}, fmt.Errorf(`invalid parameter %q: %w`, p, err)
+		}
+		params = append(params, v)
+	}
+	switch {
+	case len(params) > 2:
+		return ColumnType{}, fmt.Errorf(`too many parameters for %s`, t.Base)
+	case t.Base == "STRING" || t.Base == "BYTES":
+		if len(params) != 1 {
+			return ColumnType{}, fmt.Errorf(`too many parameters for %s`, t.Base)
+		}
+		t.Length = params[0]
+	default:
+		t.Precision = params[0]
+		if len(params) == 2 {
+			t.Scale = params[1]
+		}
+	}
+	return t, nil
+}
+
+func isIdent(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !(r == '_' || ('A' <= r && r <= 'Z') || ('a' <= r && r <= 'z') || ('0' <= r && r <= '9')) {
+			return false
+		}
+	}
+	return true
+}
+
+// closingAngle returns the index of '>' closing the already opened '<', or -1 if not found.
+func closingAngle(s string) int {
+	depth := 1
+	for i, r := range s {
+		switch r {
+		case '<':
+			depth++
+		case '>':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,41 @@
+package spanner_test
+
+import (
+	"github.com/Jumpaku/schenerate/spanner"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseColumnType(t *testing.T) {
+	testcases := []struct {
+		in      string
+		want    spanner.ColumnType
+		wantErr bool
+	}{
+		{in: "INT64", want: spanner.ColumnType{Base: "INT64"}},
+		{in: "STRING(50)", want: spanner.ColumnType{Base: "STRING", Length: 50}},
+		{in: "NUMERIC(10, 2)", want: spanner.ColumnType{Base: "NUMERIC", Precision: 10, Scale: 2}},
+		{in: "BYTES(MAX)", want: spanner.ColumnType{Base: "BYTES", Max: true}},
+		{in: "ARRAY<INT64>", want: spanner.ColumnType{Base: "ARRAY", Array: true, Elem: &spanner.ColumnType{Base: "INT64"}}},
+		{in: "ARRAY<STRING(MAX)>", want: spanner.ColumnType{Base: "ARRAY", Array: true, Elem: &spanner.ColumnType{Base: "STRING", Max: true}}},
+		{in: "ARRAY<FLOAT32>(vector_length=>128)", want: spanner.ColumnType{Base: "ARRAY", Array: true, Elem: &spanner.ColumnType{Base: "FLOAT32"}}},
+		{in: "PROTO<example.Message>", want: spanner.ColumnType{Base: "PROTO", Proto: "example.Message"}},
+		{in: "", wantErr: true},
+		{in: "STRING(", wantErr: true},
+		{in: "STRING(abc)", wantErr: true},
+		{in: "STRING(1, 2)", wantErr: true},
+		{in: "ARRAY<INT64", wantErr: true},
+		{in: "INT64<STRING>", wantErr: true},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.in, func(t *testing.T) {
+			got, err := spanner.ParseColumnType(testcase.in)
+			if testcase.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, testcase.want, got)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package spanner
+
+import (
+	"context"
+	"fmt"
+	"github.com/Jumpaku/schenerate/introspect"
+	"strings"
+)
+
+// DriverName is the name of the driver registered to introspect, whose data source is in the form of projects/<project>/instances/<instance>/databases/<database>.
+const DriverName = "spanner"
+
+func init() {
+	introspect.Register(DriverName, func(ctx context.Context, dataSource string) (introspect.Introspector, error) {
+		project, instance, database, err := parseDataSource(dataSource)
+		if err != nil {
+			return nil, err
+		}
+		return Open(ctx, project, instance, database)
+	})
+}
+
+func parseDataSource(dataSource string) (project, instance, database string, err error) {
+	parts := strings.Split(dataSource, "/")
+	if len(parts) != 6 || parts[0] != "projects" || parts[2] != "instances" || parts[4] != "databases" {
+		return "", "", "", fmt.Errorf(`invalid data source %q: projects/<project>/instances/<instance>/databases/<database> expected`, dataSource)
+	}
+	return parts[1], parts[3], parts[5], nil
+}
@@ -9,7 +9,7 @@ import (
 
 type GeneratorWithQuery[RecordStruct any] func(out *files.Writer, rows []RecordStruct) error
 
-func GenerateWithQuery[RecordStruct any](ctx context.Context, q queryer, stmt spanner.Statement, generator GeneratorWithQuery[RecordStruct]) error {
+func GenerateWithQuery[RecordStruct any](ctx context.Context, q Queryer, stmt spanner.Statement, generator GeneratorWithQuery[RecordStruct]) error {
 	rows, err := QueryRows[RecordStruct](ctx, q, stmt)
 	if err != nil {
 		return fmt.Errorf(`fail to query rows: %w`, err)
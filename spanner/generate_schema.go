@@ -4,11 +4,12 @@ import (
 	"context"
 	"fmt"
 	"github.com/Jumpaku/schenerate/files"
+	"github.com/samber/lo"
 )
 
 type GeneratorWithSchema func(out *files.Writer, schemas Schemas) error
 
-func GenerateWithSchema(ctx context.Context, q queryer, tables []string, generator GeneratorWithSchema) error {
+func GenerateWithSchema(ctx context.Context, q Queryer, tables []string, generator GeneratorWithSchema) error {
 	schemas, err := ListSchemas(ctx, q, tables)
 	if err != nil {
 		return fmt.Errorf(`fail to list schemas: %w`, err)
@@ -24,3 +25,51 @@ func GenerateWithSchema(ctx context.Context, q queryer, tables []string, generat
 	}
 	return nil
 }
+
+// GenerateWithSnapshot calls generator with the schemas loaded from the snapshot file instead of querying a database.
+func GenerateWithSnapshot(path string, generator GeneratorWithSchema) error {
+	schemas, err := LoadSnapshot(path)
+	if err != nil {
+		return fmt.Errorf(`fail to load snapshot: %w`, err)
+	}
+
+	w := &files.Writer{}
+	if err := generator(w, schemas); err != nil {
+		return err
+	}
+
+	if err := w.SaveAll(); err != nil {
+		return fmt.Errorf(`fail to save files writer: %w`, err)
+	}
+	return nil
+}
+
+// GenerateWithDDL calls generator with the schemas of the tables declared by the DDL files instead of querying a database.
+func GenerateWithDDL(ctx context.Context, ddlFiles []string, tables []string, generator GeneratorWithSchema) error {
+	ddls, err := files.ReadAll(ddlFiles...)
+	if err != nil {
+		return fmt.Errorf(`fail to read DDL files: %w`, err)
+	}
+	parsed, err := ParseDDL(ddls...)
+	if err != nil {
+		return fmt.Errorf(`fail to parse DDL: %w`, err)
+	}
+	var schemas Schemas
+	for _, t := range tables {
+		s, ok := lo.Find(parsed, func(s Schema) bool { return s.Name == t })
+		if !ok {
+			return fmt.Errorf(`fail to list schemas: table %s not found`, t)
+		}
+		schemas = append(schemas, s)
+	}
+
+	w := &files.Writer{}
+	if err := generator(w, schemas); err != nil {
+		return err
+	}
+
+	if err := w.SaveAll(); err != nil {
+		return fmt.Errorf(`fail to save files writer: %w`, err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package spanner
+
+import (
+	"github.com/Jumpaku/schenerate/gotype"
+)
+
+// NewGoTypeMapper returns a mapper from columns to Go types with the defaults of Spanner.
+func NewGoTypeMapper(style gotype.NullStyle) *gotype.Mapper {
+	return gotype.NewMapper(DefaultGoType, style)
+}
+
+// DefaultGoType returns the default mapping of the Spanner column type, which is supported by the Spanner client library.
+// Arrays are mapped to slices of the nullable element types because elements of arrays may be NULL.
+func DefaultGoType(columnType string, style gotype.NullStyle) (gotype.Mapping, bool) {
+	t, err := ParseColumnType(columnType)
+	if err != nil {
+		return gotype.Mapping{}, false
+	}
+	return defaultGoType(t, style)
+}
+
+func defaultGoType(t ColumnType, style gotype.NullStyle) (gotype.Mapping, bool) {
+	switch t.Base {
+	case "BOOL":
+		return gotype.Nullable(style, gotype.Type{Name: "bool"}, sqlGoType("NullBool"), spannerGoType("NullBool")), true
+	case "INT64":
+		return gotype.Nullable(style, gotype.Type{Name: "int64"}, sqlGoType("NullInt64"), spannerGoType("NullInt64")), true
+	case "FLOAT32":
+		return gotype.Nullable(style, gotype.Type{Name: "float32"}, gotype.Type{}, spannerGoType("NullFloat32")), true
+	case "FLOAT64":
+		return gotype.Nullable(style, gotype.Type{Name: "float64"}, sqlGoType("NullFloat64"), spannerGoType("NullFloat64")), true
+	case "STRING":
+		return gotype.Nullable(style, gotype.Type{Name: "string"}, sqlGoType("NullString"), spannerGoType("NullString")), true
+	case "BYTES", "PROTO":
+		return gotype.Same(gotype.Type{Name: "[]byte"}), true
+	case "ENUM":
+		return gotype.Nullable(style, gotype.Type{Name: "int64"}, sqlGoType("NullInt64"), spannerGoType("NullInt64")), true
+	case "DATE":
+		return gotype.Nullable(style, gotype.Type{Name: "civil.Date", Import: "cloud.google.com/go/civil"}, gotype.Type{}, spannerGoType("NullDate")), true
+	case "TIMESTAMP":
+		return gotype.Nullable(style, gotype.Type{Name: "time.Time", Import: "time"}, sqlGoType("NullTime"), spannerGoType("NullTime")), true
+	case "NUMERIC":
+		return gotype.Nullable(style, gotype.Type{Name: "big.Rat", Import: "math/big"}, gotype.Type{}, spannerGoType("NullNumeric")), true
+	case "JSON":
+		return gotype.Same(spannerGoType("NullJSON")), true
+	case "ARRAY":
+		if t.Elem == nil {
+			return gotype.Mapping{}, false
+		}
+		elem, ok := defaultGoType(*t.Elem, style)
+		if !ok {
+			return gotype.Mapping{}, false
+		}
+		if elem.Null.Name == "" {
+			elem.Null = elem.Type.Pointer()
+		}
+		return gotype.Same(elem.Null.Slice()), true
+	default:
+		return gotype.Mapping{}, false
+	}
+}
+
+func spannerGoType(name string) gotype.Type {
+	return gotype.Type{Name: "spanner." + name, Import: "cloud.google.com/go/spanner"}
+}
+
+func sqlGoType(name string) gotype.Type {
+	return gotype.Type{Name: "sql." + name, Import: "database/sql"}
+}
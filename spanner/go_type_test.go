@@ -0,0 +1,30 @@
+package spanner_test
+
+import (
+	"github.com/Jumpaku/schenerate/gotype"
+	"github.com/Jumpaku/schenerate/spanner"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDefaultGoType(t *testing.T) {
+	testcases := []struct {
+		in    string
+		style gotype.NullStyle
+		want  gotype.Mapping
+	}{
+		{in: "INT64", style: gotype.NullStylePointer, want: gotype.Mapping{Type: gotype.Type{Name: "int64"}}},
+		{in: "STRING(50)", style: gotype.NullStyleDatabaseSQL, want: gotype.Mapping{Type: gotype.Type{Name: "string"}, Null: gotype.Type{Name: "sql.NullString", Import: "database/sql"}}},
+		{in: "DATE", style: gotype.NullStyleDriver, want: gotype.Mapping{Type: gotype.Type{Name: "civil.Date", Import: "cloud.google.com/go/civil"}, Null: gotype.Type{Name: "spanner.NullDate", Import: "cloud.google.com/go/spanner"}}},
+		{in: "BYTES(MAX)", style: gotype.NullStyleDriver, want: gotype.Same(gotype.Type{Name: "[]byte"})},
+		{in: "ARRAY<INT64>", style: gotype.NullStylePointer, want: gotype.Same(gotype.Type{Name: "[]*int64"})},
+		{in: "ARRAY<STRING(MAX)>", style: gotype.NullStyleDriver, want: gotype.Same(gotype.Type{Name: "[]spanner.NullString", Import: "cloud.google.com/go/spanner"})},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.in, func(t *testing.T) {
+			got, ok := spanner.DefaultGoType(testcase.in, testcase.style)
+			require.True(t, ok)
+			require.Equal(t, testcase.want, got)
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package spanner_test
+
+import (
+	"fmt"
+	"github.com/Jumpaku/schenerate/introspect"
+	"github.com/Jumpaku/schenerate/introspect/introspecttest"
+	"github.com/Jumpaku/schenerate/spanner"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestIntrospector(t *testing.T) {
+	number := 0
+	introspecttest.Run(t, introspecttest.Config{
+		Setup: func(t *testing.T, ddl string) introspect.Introspector {
+			number++
+			database := fmt.Sprintf(`introspect_%d_%d`, number, time.Now().Unix())
+			q, teardown := spanner.Setup(t, database, []string{ddl})
+			t.Cleanup(teardown)
+			return q
+		},
+		DDL: os.DirFS("testdata/list"),
+	})
+}
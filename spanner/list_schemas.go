@@ -4,11 +4,65 @@ import (
 	"cloud.google.com/go/spanner"
 	"context"
 	"fmt"
+	"github.com/Jumpaku/schenerate/schema"
 	"github.com/samber/lo"
 	"slices"
 )
 
-func ListSchemas(ctx context.Context, q queryer, tables []string) (schemas Schemas, err error) {
+// ListSchemas lists the schemas of the tables, which are expanded by the references and the referrers according to opts.
+// The tables in the named schemas are specified and named by the names qualified by the schemas, e.g. Schema.Table.
+func ListSchemas(ctx context.Context, q Queryer, tables []string, opts ...schema.ListOption) (Schemas, error) {
+	return schema.Expand(ctx, tables, opts,
+		func(ctx context.Context, tables []string) ([]Schema, error) { return listSchemas(ctx, q, tables) },
+		func(s Schema) schema.Schema { return Schemas{s}.Unified()[0] },
+		func(ctx context.Context) (map[string][]string, error) { return listReferences(ctx, q) },
+	)
+}
+
+// listReferences returns the tables referenced by each table through foreign keys and interleaving by a single query.
+func listReferences(ctx context.Context, q Queryer) (map[string][]string, error) {
+	tx := q.client.ReadOnlyTransaction()
+	defer tx.Close()
+
+	type reference struct {
+		Schema           string `db:"Schema"`
+		Name             string `db:"Name"`
+		ReferencedSchema string `db:"ReferencedSchema"`
+		ReferencedTable  string `db:"ReferencedTable"`
+	}
+	rows, err := query[reference](ctx, tx, spanner.Statement{
+		//language=SQL
+		SQL: `--sql query references of all the tables
+SELECT
+	tc.TABLE_SCHEMA AS Schema,
+	tc.TABLE_NAME AS Name,
+	ctu.TABLE_SCHEMA AS ReferencedSchema,
+	ctu.TABLE_NAME AS ReferencedTable
+FROM
+	INFORMATION_SCHEMA.TABLE_CONSTRAINTS tc
+	JOIN INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS rc
+		ON rc.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND rc.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+	JOIN INFORMATION_SCHEMA.CONSTRAINT_TABLE_USAGE ctu
+		ON ctu.CONSTRAINT_SCHEMA = rc.UNIQUE_CONSTRAINT_SCHEMA AND ctu.CONSTRAINT_NAME = rc.UNIQUE_CONSTRAINT_NAME
+WHERE tc.CONSTRAINT_TYPE = 'FOREIGN KEY'
+UNION DISTINCT
+SELECT TABLE_SCHEMA, TABLE_NAME, TABLE_SCHEMA, PARENT_TABLE_NAME
+FROM INFORMATION_SCHEMA.TABLES
+WHERE PARENT_TABLE_NAME IS NOT NULL
+ORDER BY Schema, Name, ReferencedSchema, ReferencedTable`,
+	})
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get references: %w`, err)
+	}
+	refs := map[string][]string{}
+	for _, r := range rows {
+		name := qualifiedName(r.Schema, r.Name)
+		refs[name] = append(refs[name], qualifiedName(r.ReferencedSchema, r.ReferencedTable))
+	}
+	return refs, nil
+}
+
+func listSchemas(ctx context.Context, q Queryer, tables []string) (schemas Schemas, err error) {
 	tx := q.client.ReadOnlyTransaction()
 	defer tx.Close()
 
@@ -81,12 +135,12 @@ func queryTables(ctx context.Context, tx *spanner.ReadOnlyTransaction, tables []
 		//language=SQL
 		SQL: `--sql query table information
 SELECT
-	TABLE_NAME AS Name,
+	IF(TABLE_SCHEMA = '', TABLE_NAME, CONCAT(TABLE_SCHEMA, '.', TABLE_NAME)) AS Name,
 	TABLE_TYPE AS Type,
-	IFNULL(PARENT_TABLE_NAME, '') AS Parent
+	IF(PARENT_TABLE_NAME IS NULL, '', IF(TABLE_SCHEMA = '', PARENT_TABLE_NAME, CONCAT(TABLE_SCHEMA, '.', PARENT_TABLE_NAME))) AS Parent
 FROM INFORMATION_SCHEMA.TABLES
-WHERE TABLE_NAME IN UNNEST(@Tables)
-ORDER BY TABLE_NAME`,
+WHERE IF(TABLE_SCHEMA = '', TABLE_NAME, CONCAT(TABLE_SCHEMA, '.', TABLE_NAME)) IN UNNEST(@Tables)
+ORDER BY Name`,
 		Params: map[string]interface{}{"Tables": tables},
 	})
 	if err != nil {
@@ -125,7 +179,7 @@ SELECT
 			SPANNER_TYPE AS Type,
 			(IS_NULLABLE = 'YES') AS Nullable,
 		FROM INFORMATION_SCHEMA.COLUMNS
-		WHERE TABLE_NAME = TableName
+		WHERE IF(TABLE_SCHEMA = '', TABLE_NAME, CONCAT(TABLE_SCHEMA, '.', TABLE_NAME)) = TableName
 		ORDER BY ORDINAL_POSITION
 	) AS TableColumns
 FROM UNNEST(@Tables) AS TableName`,
@@ -156,9 +210,9 @@ SELECT
 		SELECT AS VALUE kcu.COLUMN_NAME
 		FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS AS tc
 			JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE AS kcu
-			ON kcu.CONSTRAINT_NAME = tc.CONSTRAINT_NAME 
-				AND kcu.TABLE_NAME = tc.TABLE_NAME
-		WHERE kcu.TABLE_NAME = TableName AND tc.CONSTRAINT_TYPE = 'PRIMARY KEY'
+			ON kcu.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND kcu.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+				AND kcu.TABLE_SCHEMA = tc.TABLE_SCHEMA AND kcu.TABLE_NAME = tc.TABLE_NAME
+		WHERE IF(kcu.TABLE_SCHEMA = '', kcu.TABLE_NAME, CONCAT(kcu.TABLE_SCHEMA, '.', kcu.TABLE_NAME)) = TableName AND tc.CONSTRAINT_TYPE = 'PRIMARY KEY'
 		ORDER BY kcu.ORDINAL_POSITION
 	) AS TablePrimaryKey
 FROM UNNEST(@Tables) AS TableName`,
@@ -193,22 +247,24 @@ SELECT
 			tc.CONSTRAINT_NAME AS Name,
 			ARRAY(
 				SELECT kcu.COLUMN_NAME
-				FROM INFORMATION_SCHEMA.KEY_COLUMN_USAGE kcu 
-				WHERE kcu.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+				FROM INFORMATION_SCHEMA.KEY_COLUMN_USAGE kcu
+				WHERE kcu.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND kcu.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
 				ORDER BY kcu.ORDINAL_POSITION
 			) AS Key,
-			ctu.TABLE_NAME AS ReferenceTable,
+			IF(ctu.TABLE_SCHEMA = '', ctu.TABLE_NAME, CONCAT(ctu.TABLE_SCHEMA, '.', ctu.TABLE_NAME)) AS ReferenceTable,
 			ARRAY(
 				SELECT kcu.COLUMN_NAME
-				FROM INFORMATION_SCHEMA.KEY_COLUMN_USAGE kcu 
-				WHERE kcu.CONSTRAINT_NAME = rc.UNIQUE_CONSTRAINT_NAME
+				FROM INFORMATION_SCHEMA.KEY_COLUMN_USAGE kcu
+				WHERE kcu.CONSTRAINT_SCHEMA = rc.UNIQUE_CONSTRAINT_SCHEMA AND kcu.CONSTRAINT_NAME = rc.UNIQUE_CONSTRAINT_NAME
 				ORDER BY kcu.ORDINAL_POSITION
 			) AS ReferenceKey
 		FROM
 			INFORMATION_SCHEMA.TABLE_CONSTRAINTS tc
-			JOIN INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS rc ON rc.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
-			JOIN INFORMATION_SCHEMA.CONSTRAINT_TABLE_USAGE ctu ON ctu.CONSTRAINT_NAME = rc.UNIQUE_CONSTRAINT_NAME
-		WHERE tc.CONSTRAINT_TYPE = 'FOREIGN KEY' AND tc.TABLE_NAME = TableName
+			JOIN INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS rc
+				ON rc.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND rc.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+			JOIN INFORMATION_SCHEMA.CONSTRAINT_TABLE_USAGE ctu
+				ON ctu.CONSTRAINT_SCHEMA = rc.UNIQUE_CONSTRAINT_SCHEMA AND ctu.CONSTRAINT_NAME = rc.UNIQUE_CONSTRAINT_NAME
+		WHERE tc.CONSTRAINT_TYPE = 'FOREIGN KEY' AND IF(tc.TABLE_SCHEMA = '', tc.TABLE_NAME, CONCAT(tc.TABLE_SCHEMA, '.', tc.TABLE_NAME)) = TableName
 		ORDER BY Name
 	) AS TableForeignKeys
 FROM UNNEST(@Tables) AS TableName`,
@@ -270,12 +326,12 @@ SELECT
 					idxc.COLUMN_NAME AS Name,
 					idxc.COLUMN_ORDERING = 'DESC' AS IsDesc
 				FROM INFORMATION_SCHEMA.INDEX_COLUMNS idxc
-				WHERE idx.INDEX_NAME = idxc.INDEX_NAME AND idx.TABLE_NAME = idxc.TABLE_NAME
+				WHERE idx.INDEX_NAME = idxc.INDEX_NAME AND idx.TABLE_SCHEMA = idxc.TABLE_SCHEMA AND idx.TABLE_NAME = idxc.TABLE_NAME
 				ORDER BY idxc.ORDINAL_POSITION
 			) AS Key,
 		FROM INFORMATION_SCHEMA.INDEXES idx
 		WHERE
-			idx.TABLE_NAME = TableName
+			IF(idx.TABLE_SCHEMA = '', idx.TABLE_NAME, CONCAT(idx.TABLE_SCHEMA, '.', idx.TABLE_NAME)) = TableName
 			AND INDEX_TYPE = "INDEX"
 			AND idx.INDEX_NAME NOT IN (SELECT Name FROM EXCLUDE_FK_BACKING)
 		ORDER BY Name
@@ -4,24 +4,59 @@ import (
 	"cloud.google.com/go/spanner"
 	"context"
 	"fmt"
+	"github.com/Jumpaku/schenerate/schema"
 	"github.com/samber/lo"
 )
 
-func ListTables(ctx context.Context, q queryer) ([]string, error) {
+func ListTables(ctx context.Context, q Queryer) ([]string, error) {
+	tables, err := listTables(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(tables, func(t schema.Table, _ int) string { return qualifiedName(t.Schema, t.Name) }), nil
+}
+
+// SelectTables returns the names of the tables selected by the selector, which can be passed to ListSchemas.
+// The names of the tables in the named schemas are qualified by the schemas.
+func SelectTables(ctx context.Context, q Queryer, selector schema.Selector) ([]string, error) {
+	tables, err := listTables(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	selected, err := selector.Select(tables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select tables: %w", err)
+	}
+	return lo.Map(selected, func(t schema.Table, _ int) string { return qualifiedName(t.Schema, t.Name) }), nil
+}
+
+func listTables(ctx context.Context, q Queryer) ([]schema.Table, error) {
 	tx := q.client.ReadOnlyTransaction()
 	defer tx.Close()
 
 	type table struct {
-		Name string `db:"Name"`
+		Schema string `db:"Schema"`
+		Name   string `db:"Name"`
+		Type   string `db:"Type"`
 	}
 	records, err := query[table](ctx, tx, spanner.Statement{
-		SQL: `SELECT TABLE_NAME AS Name
+		SQL: `SELECT TABLE_SCHEMA AS Schema, TABLE_NAME AS Name, TABLE_TYPE AS Type
 FROM INFORMATION_SCHEMA.TABLES
-WHERE TABLE_SCHEMA = ''
-ORDER BY TABLE_NAME`,
+WHERE TABLE_SCHEMA NOT IN ('INFORMATION_SCHEMA', 'SPANNER_SYS')
+ORDER BY TABLE_SCHEMA, TABLE_NAME`,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list tables: %w", err)
 	}
-	return lo.Map(records, func(r table, _ int) string { return r.Name }), nil
+	return lo.Map(records, func(r table, _ int) schema.Table {
+		return schema.Table{Schema: r.Schema, Name: r.Name, Type: r.Type}
+	}), nil
+}
+
+// qualifiedName returns the name of the table qualified by the schema unless the schema is the default schema.
+func qualifiedName(schemaName, table string) string {
+	if schemaName == "" {
+		return table
+	}
+	return schemaName + "." + table
 }
@@ -0,0 +1,32 @@
+package spanner
+
+import (
+	"context"
+	"fmt"
+	"github.com/Jumpaku/schenerate/migrations"
+)
+
+// ListSchemasFromMigrations parses the up-migrations in the directory up to and including the version with ParseDDL
+// and returns the schemas of all the tables, where all the migrations are applied if the version is empty.
+// Unlike the other backends, the migrations are not applied to a database but interpreted by ParseDDL,
+// so statements other than those of tables and indexes, such as CREATE VIEW and DML, are ignored,
+// syntax that ParseDDL does not support fails, and unnamed foreign keys have empty names.
+func ListSchemasFromMigrations(ctx context.Context, dir string, version string) (Schemas, error) {
+	ms, err := migrations.Load(dir)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to load migrations: %w`, err)
+	}
+	ms, err = ms.UpTo(version)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to select migrations: %w`, err)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	schemas, err := ParseDDL(ms.Up()...)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to apply migrations: %w`, err)
+	}
+	return schemas, nil
+}
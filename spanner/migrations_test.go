@@ -0,0 +1,51 @@
+package spanner_test
+
+import (
+	"context"
+	"github.com/Jumpaku/schenerate/spanner"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestListSchemasFromMigrations(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"V1__create_a.sql": `CREATE TABLE A (Id INT64 NOT NULL) PRIMARY KEY (Id);`,
+		"V1_1__create_b.sql": `CREATE TABLE B (Id INT64 NOT NULL, Sub INT64 NOT NULL) PRIMARY KEY (Id, Sub), INTERLEAVE IN PARENT A;
+CREATE INDEX IDX_B_Sub ON B (Sub);`,
+		"V2__drop_index.sql": `DROP INDEX IDX_B_Sub;`,
+	} {
+		require.Nil(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	testcases := []struct {
+		name        string
+		version     string
+		wantTables  []string
+		wantIndexes int
+		wantErr     bool
+	}{
+		{name: "latest", version: "", wantTables: []string{"A", "B"}, wantIndexes: 0},
+		{name: "version 1.1", version: "1.1", wantTables: []string{"A", "B"}, wantIndexes: 1},
+		{name: "version 1", version: "1", wantTables: []string{"A"}},
+		{name: "unknown version", version: "3", wantErr: true},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			got, err := spanner.ListSchemasFromMigrations(context.Background(), dir, testcase.version)
+			if testcase.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, testcase.wantTables, lo.Map(got, func(s spanner.Schema, _ int) string { return s.Name }))
+			if len(got) > 1 {
+				require.Equal(t, "A", got[1].Parent)
+				require.Len(t, got[1].Indexes, testcase.wantIndexes)
+			}
+		})
+	}
+}
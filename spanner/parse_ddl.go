@@ -0,0 +1,613 @@
+package spanner
+
+import (
+	"cmp"
+	"fmt"
+	"github.com/Jumpaku/sqanner/tokenize"
+	"github.com/samber/lo"
+	"slices"
+	"strings"
+)
+
+// ParseDDL builds the schemas of the tables declared by the DDL statements without a database.
+// The statements are applied in order, so that ALTER TABLE, DROP TABLE and DROP INDEX take effect on the preceding statements.
+// The schemas are in the same form as ListSchemas except that the names of unnamed foreign keys are empty.
+// Statements other than those of tables and indexes, such as CREATE VIEW, are ignored.
+func ParseDDL(ddls ...string) (Schemas, error) {
+	p := &ddlParser{tables: map[string]*Schema{}}
+	for _, ddl := range ddls {
+		statements, err := tokenizeStatements(ddl)
+		if err != nil {
+			return nil, fmt.Errorf(`fail to tokenize DDL: %w`, err)
+		}
+		for _, tokens := range statements {
+			s := &statement{tokens: tokens}
+			if err := p.parse(s); err != nil {
+				return nil, fmt.Errorf(`fail to parse DDL %q: %w`, s.String(), err)
+			}
+		}
+	}
+	return p.schemas(), nil
+}
+
+// splitStatements splits the DDL into statements, in which comments and spaces are replaced with single spaces.
+func splitStatements(ddl string) ([]string, error) {
+	statements, err := tokenizeStatements(ddl)
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(statements, func(tokens []tokenize.Token, _ int) string {
+		return (&statement{tokens: tokens}).String()
+	}), nil
+}
+
+// tokenizeStatements tokenizes the DDL and groups the tokens by statements separated by semicolons.
+// Comments and spaces are removed and empty statements are skipped.
+func tokenizeStatements(ddl string) ([][]tokenize.Token, error) {
+	tokens, err := tokenize.Tokenize([]rune(ddl))
+	if err != nil {
+		return nil, err
+	}
+	var statements [][]tokenize.Token
+	var current []tokenize.Token
+	for _, token := range tokens {
+		switch {
+		case token.Kind == tokenize.TokenComment || token.Kind == tokenize.TokenSpace:
+		case token.Kind == tokenize.TokenEOF || (token.Kind == tokenize.TokenSpecialChar && string(token.Content) == ";"):
+			if len(current) > 0 {
+				statements = append(statements, current)
+			}
+			current = nil
+		default:
+			current = append(current, token)
+		}
+	}
+	return statements, nil
+}
+
+type ddlParser struct {
+	order  []string
+	tables map[string]*Schema
+}
+
+func (p *ddlParser) schemas() Schemas {
+	order := slices.Clone(p.order)
+	slices.Sort(order)
+	return lo.Map(order, func(name string, _ int) Schema {
+		s := *p.tables[name]
+		slices.SortStableFunc(s.ForeignKeys, func(a, b ForeignKey) int { return cmp.Compare(a.Name, b.Name) })
+		slices.SortStableFunc(s.Indexes, func(a, b Index) int { return cmp.Compare(a.Name, b.Name) })
+		return s
+	})
+}
+
+func (p *ddlParser) table(name string) (*Schema, error) {
+	t, ok := p.tables[name]
+	if !ok {
+		return nil, fmt.Errorf(`table %s not found`, name)
+	}
+	return t, nil
+}
+
+func (p *ddlParser) parse(s *statement) error {
+	switch {
+	case s.acceptWords("CREATE", "TABLE"):
+		return p.createTable(s)
+	case s.acceptWords("CREATE", "UNIQUE", "NULL_FILTERED", "INDEX"):
+		return p.createIndex(s, true)
+	case s.acceptWords("CREATE", "UNIQUE", "INDEX"):
+		return p.createIndex(s, true)
+	case s.acceptWords("CREATE", "NULL_FILTERED", "INDEX"):
+		return p.createIndex(s, false)
+	case s.acceptWords("CREATE", "INDEX"):
+		return p.createIndex(s, false)
+	case s.acceptWords("ALTER", "TABLE"):
+		return p.alterTable(s)
+	case s.acceptWords("DROP", "TABLE"):
+		return p.dropTable(s)
+	case s.acceptWords("DROP", "INDEX"):
+		return p.dropIndex(s)
+	default:
+		return nil
+	}
+}
+
+func (p *ddlParser) hasIndex(name string) bool {
+	return lo.SomeBy(lo.Values(p.tables), func(t *Schema) bool {
+		return lo.ContainsBy(t.Indexes, func(idx Index) bool { return idx.Name == name })
+	})
+}
+
+func (p *ddlParser) createTable(s *statement) error {
+	ifNotExists := s.acceptWords("IF", "NOT", "EXISTS")
+	name, err := s.name()
+	if err != nil {
+		return err
+	}
+	if _, ok := p.tables[name]; ok {
+		if ifNotExists {
+			return nil
+		}
+		return fmt.Errorf(`table %s already exists`, name)
+	}
+	t := &Schema{Name: name, Type: "BASE TABLE"}
+	if err := s.expectChar("("); err != nil {
+		return err
+	}
+	for !s.acceptChar(")") {
+		switch {
+		case s.isWord("CONSTRAINT"), s.isWord("FOREIGN"), s.isWord("CHECK"):
+			fk, err := s.constraint()
+			if err != nil {
+				return err
+			}
+			if fk != nil {
+				t.ForeignKeys = append(t.ForeignKeys, *fk)
+			}
+		case s.isWord("SYNONYM"):
+			s.skipElement()
+		default:
+			c, err := s.column()
+			if err != nil {
+				return err
+			}
+			t.Columns = append(t.Columns, c)
+		}
+		if !s.acceptChar(",") {
+			if err := s.expectChar(")"); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	if err := s.expectWords("PRIMARY", "KEY"); err != nil {
+		return err
+	}
+	pk, err := s.keyList()
+	if err != nil {
+		return err
+	}
+	t.PrimaryKey = lo.Map(pk, func(k IndexKeyElem, _ int) string { return k.Name })
+	for s.acceptChar(",") {
+		if s.acceptWords("INTERLEAVE", "IN") {
+			s.acceptWords("PARENT")
+			if t.Parent, err = s.name(); err != nil {
+				return err
+			}
+		}
+		s.skipElement()
+	}
+	if !s.eof() {
+		return fmt.Errorf(`unexpected %q`, string(s.peek().Content))
+	}
+
+	p.order = append(p.order, name)
+	p.tables[name] = t
+	return nil
+}
+
+func (p *ddlParser) createIndex(s *statement, unique bool) error {
+	ifNotExists := s.acceptWords("IF", "NOT", "EXISTS")
+	name, err := s.name()
+	if err != nil {
+		return err
+	}
+	if p.hasIndex(name) {
+		if ifNotExists {
+			return nil
+		}
+		return fmt.Errorf(`index %s already exists`, name)
+	}
+	if err := s.expectWords("ON"); err != nil {
+		return err
+	}
+	tableName, err := s.name()
+	if err != nil {
+		return err
+	}
+	t, err := p.table(tableName)
+	if err != nil {
+		return err
+	}
+	key, err := s.keyList()
+	if err != nil {
+		return err
+	}
+	t.Indexes = append(t.Indexes, Index{Name: name, Unique: unique, Key: key})
+	return nil
+}
+
+func (p *ddlParser) alterTable(s *statement) error {
+	name, err := s.name()
+	if err != nil {
+		return err
+	}
+	t, err := p.table(name)
+	if err != nil {
+		return err
+	}
+	switch {
+	case s.acceptWords("ADD", "COLUMN"):
+		ifNotExists := s.acceptWords("IF", "NOT", "EXISTS")
+		c, err := s.column()
+		if err != nil {
+			return err
+		}
+		if lo.ContainsBy(t.Columns, func(col Column) bool { return col.Name == c.Name }) {
+			if ifNotExists {
+				return nil
+			}
+			return fmt.Errorf(`column %s already exists in %s`, c.Name, name)
+		}
+		t.Columns = append(t.Columns, c)
+	case s.acceptWords("ADD", "ROW"), s.acceptWords("ADD", "SYNONYM"):
+	case s.acceptWords("ADD"):
+		fk, err := s.constraint()
+		if err != nil {
+			return err
+		}
+		if fk != nil {
+			t.ForeignKeys = append(t.ForeignKeys, *fk)
+		}
+	case s.acceptWords("DROP", "COLUMN"):
+		c, err := s.name()
+		if err != nil {
+			return err
+		}
+		t.Columns = lo.Reject(t.Columns, func(col Column, _ int) bool { return col.Name == c })
+	case s.acceptWords("DROP", "CONSTRAINT"):
+		c, err := s.name()
+		if err != nil {
+			return err
+		}
+		t.ForeignKeys = lo.Reject(t.ForeignKeys, func(fk ForeignKey, _ int) bool { return fk.Name == c })
+	case s.acceptWords("ALTER", "COLUMN"):
+		if s.isWordAt(1, "SET") || s.isWordAt(1, "DROP") {
+			return nil
+		}
+		c, err := s.column()
+		if err != nil {
+			return err
+		}
+		i := slices.IndexFunc(t.Columns, func(col Column) bool { return col.Name == c.Name })
+		if i < 0 {
+			return fmt.Errorf(`column %s not found in %s`, c.Name, name)
+		}
+		t.Columns[i] = c
+	case s.acceptWords("DROP", "ROW"), s.acceptWords("REPLACE", "ROW"), s.acceptWords("DROP", "SYNONYM"), s.acceptWords("SET"):
+	default:
+		return fmt.Errorf(`unsupported ALTER TABLE %q`, string(s.peek().Content))
+	}
+	return nil
+}
+
+func (p *ddlParser) dropTable(s *statement) error {
+	ifExists := s.acceptWords("IF", "EXISTS")
+	name, err := s.name()
+	if err != nil {
+		return err
+	}
+	if _, ok := p.tables[name]; !ok {
+		if ifExists {
+			return nil
+		}
+		return fmt.Errorf(`table %s not found`, name)
+	}
+	delete(p.tables, name)
+	p.order = lo.Without(p.order, name)
+	return nil
+}
+
+func (p *ddlParser) dropIndex(s *statement) error {
+	s.acceptWords("IF", "EXISTS")
+	name, err := s.name()
+	if err != nil {
+		return err
+	}
+	for _, t := range p.tables {
+		t.Indexes = lo.Reject(t.Indexes, func(idx Index, _ int) bool { return idx.Name == name })
+	}
+	return nil
+}
+
+// statement is a cursor over the tokens of a statement without comments and spaces.
+type statement struct {
+	tokens []tokenize.Token
+	pos    int
+}
+
+func (s *statement) String() string {
+	var b strings.Builder
+	for i, t := range s.tokens {
+		if i > 0 && isWordToken(s.tokens[i-1]) && isWordToken(t) {
+			b.WriteString(" ")
+		}
+		if i > 0 && string(s.tokens[i-1].Content) == "," {
+			b.WriteString(" ")
+		}
+		b.WriteString(string(t.Content))
+	}
+	return b.String()
+}
+
+func (s *statement) eof() bool {
+	return s.pos >= len(s.tokens)
+}
+
+func (s *statement) peek() tokenize.Token {
+	return s.peekAt(0)
+}
+
+func (s *statement) peekAt(offset int) tokenize.Token {
+	if s.pos+offset >= len(s.tokens) {
+		return tokenize.Token{Kind: tokenize.TokenEOF}
+	}
+	return s.tokens[s.pos+offset]
+}
+
+func (s *statement) isWord(word string) bool {
+	return s.isWordAt(0, word)
+}
+
+func (s *statement) isWordAt(offset int, word string) bool {
+	t := s.peekAt(offset)
+	return (t.Kind == tokenize.TokenKeyword || t.Kind == tokenize.TokenIdentifier) && strings.EqualFold(string(t.Content), word)
+}
+
+func (s *statement) isChar(c string) bool {
+	t := s.peek()
+	return t.Kind == tokenize.TokenSpecialChar && string(t.Content) == c
+}
+
+func (s *statement) acceptWords(words ...string) bool {
+	for i, w := range words {
+		if !s.isWordAt(i, w) {
+			return false
+		}
+	}
+	s.pos += len(words)
+	return true
+}
+
+func (s *statement) expectWords(words ...string) error {
+	if !s.acceptWords(words...) {
+		return fmt.Errorf(`%s expected but %q found`, strings.Join(words, " "), string(s.peek().Content))
+	}
+	return nil
+}
+
+func (s *statement) acceptChar(c string) bool {
+	if !s.isChar(c) {
+		return false
+	}
+	s.pos++
+	return true
+}
+
+func (s *statement) expectChar(c string) error {
+	if !s.acceptChar(c) {
+		return fmt.Errorf(`%q expected but %q found`, c, string(s.peek().Content))
+	}
+	return nil
+}
+
+// name consumes an identifier, which may be quoted or qualified with a named schema.
+func (s *statement) name() (string, error) {
+	var parts []string
+	for {
+		t := s.peek()
+		switch t.Kind {
+		case tokenize.TokenIdentifier:
+			parts = append(parts, string(t.Content))
+		case tokenize.TokenIdentifierQuoted:
+			parts = append(parts, strings.Trim(string(t.Content), "`"))
+		default:
+			return "", fmt.Errorf(`identifier expected but %q found`, string(t.Content))
+		}
+		s.pos++
+		if !s.acceptChar(".") {
+			return strings.Join(parts, "."), nil
+		}
+	}
+}
+
+func (s *statement) nameList() ([]string, error) {
+	if err := s.expectChar("("); err != nil {
+		return nil, err
+	}
+	var names []string
+	for {
+		name, err := s.name()
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+		if !s.acceptChar(",") {
+			break
+		}
+	}
+	if err := s.expectChar(")"); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+func (s *statement) keyList() ([]IndexKeyElem, error) {
+	if err := s.expectChar("("); err != nil {
+		return nil, err
+	}
+	if s.acceptChar(")") {
+		return nil, nil
+	}
+	var key []IndexKeyElem
+	for {
+		name, err := s.name()
+		if err != nil {
+			return nil, err
+		}
+		desc := s.acceptWords("DESC")
+		if !desc {
+			s.acceptWords("ASC")
+		}
+		key = append(key, IndexKeyElem{Name: name, Desc: desc})
+		if !s.acceptChar(",") {
+			break
+		}
+	}
+	if err := s.expectChar(")"); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// skipElement skips tokens until a comma or a closing parenthesis outside any parentheses.
+func (s *statement) skipElement() {
+	depth := 0
+	for !s.eof() {
+		switch {
+		case s.isChar("("):
+			depth++
+		case s.isChar(")"):
+			if depth == 0 {
+				return
+			}
+			depth--
+		case s.isChar(","):
+			if depth == 0 {
+				return
+			}
+		}
+		s.pos++
+	}
+}
+
+func (s *statement) column() (Column, error) {
+	name, err := s.name()
+	if err != nil {
+		return Column{}, err
+	}
+	columnType, err := s.columnType()
+	if err != nil {
+		return Column{}, err
+	}
+	c := Column{Name: name, Type: columnType, Nullable: true}
+	depth := 0
+	for !s.eof() {
+		switch {
+		case depth == 0 && s.acceptWords("NOT", "NULL"):
+			c.Nullable = false
+			continue
+		case s.isChar("("):
+			depth++
+		case s.isChar(")"):
+			if depth == 0 {
+				return c, nil
+			}
+			depth--
+		case s.isChar(","):
+			if depth == 0 {
+				return c, nil
+			}
+		}
+		s.pos++
+	}
+	return c, nil
+}
+
+// columnType consumes a column type and returns it in the form of SPANNER_TYPE in INFORMATION_SCHEMA.COLUMNS.
+func (s *statement) columnType() (string, error) {
+	if !isWordToken(s.peek()) {
+		return "", fmt.Errorf(`column type expected but %q found`, string(s.peek().Content))
+	}
+	var b strings.Builder
+	var prev tokenize.Token
+	angles, parens := 0, 0
+	for !s.eof() {
+		t := s.peek()
+		if angles == 0 && parens == 0 && prev.Kind != tokenize.TokenUnspecified &&
+			!s.isChar("<") && !s.isChar("(") && !s.isChar(".") && string(prev.Content) != "." {
+			break
+		}
+		switch {
+		case s.isChar("("):
+			parens++
+		case s.isChar(")"):
+			parens--
+		case parens == 0 && s.isChar("<"):
+			angles++
+		case parens == 0 && s.isChar(">"):
+			angles--
+		}
+		switch {
+		case isWordToken(prev) && isWordToken(t), string(prev.Content) == ",":
+			b.WriteString(" ")
+		}
+		b.WriteString(typeToken(t))
+		prev = t
+		s.pos++
+	}
+	if angles != 0 || parens != 0 {
+		return "", fmt.Errorf(`unbalanced column type %q`, b.String())
+	}
+	return b.String(), nil
+}
+
+// constraint consumes a table constraint and returns the foreign key, or nil if the constraint is not a foreign key.
+func (s *statement) constraint() (*ForeignKey, error) {
+	var name string
+	if s.acceptWords("CONSTRAINT") {
+		var err error
+		if name, err = s.name(); err != nil {
+			return nil, err
+		}
+	}
+	if !s.acceptWords("FOREIGN", "KEY") {
+		s.skipElement()
+		return nil, nil
+	}
+	key, err := s.nameList()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.expectWords("REFERENCES"); err != nil {
+		return nil, err
+	}
+	table, err := s.name()
+	if err != nil {
+		return nil, err
+	}
+	referenceKey, err := s.nameList()
+	if err != nil {
+		return nil, err
+	}
+	s.skipElement()
+	return &ForeignKey{Name: name, Key: key, Reference: ForeignKeyReference{Table: table, Key: referenceKey}}, nil
+}
+
+var builtinTypes = map[string]bool{
+	"ARRAY": true, "BOOL": true, "BYTES": true, "DATE": true, "FLOAT32": true, "FLOAT64": true, "INT64": true,
+	"INTERVAL": true, "JSON": true, "MAX": true, "NUMERIC": true, "STRING": true, "STRUCT": true, "TIMESTAMP": true,
+	"TOKENLIST": true,
+}
+
+func typeToken(t tokenize.Token) string {
+	content := string(t.Content)
+	switch t.Kind {
+	case tokenize.TokenIdentifierQuoted:
+		return strings.Trim(content, "`")
+	case tokenize.TokenKeyword, tokenize.TokenIdentifier:
+		if builtinTypes[strings.ToUpper(content)] {
+			return strings.ToUpper(content)
+		}
+	}
+	return content
+}
+
+func isWordToken(t tokenize.Token) bool {
+	switch t.Kind {
+	case tokenize.TokenKeyword, tokenize.TokenIdentifier, tokenize.TokenIdentifierQuoted,
+		tokenize.TokenLiteralInteger, tokenize.TokenLiteralFloat, tokenize.TokenLiteralQuoted:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+package spanner_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/Jumpaku/schenerate/files"
+	"github.com/Jumpaku/schenerate/spanner"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseDDL(t *testing.T) {
+	testcases := []struct {
+		name    string
+		ddls    []string
+		want    spanner.Schemas
+		wantErr bool
+	}{
+		{
+			name: "column types and options",
+			ddls: []string{`
+CREATE TABLE IF NOT EXISTS ` + "`T`" + ` (
+    Id STRING(MAX) NOT NULL DEFAULT (GENERATE_UUID()),
+    Tags ARRAY<string(20)> NOT NULL,
+    Embedding ARRAY<FLOAT32>(vector_length=>128),
+    Amount NUMERIC OPTIONS (allow_commit_timestamp = false),
+    Total INT64 AS (LENGTH(Id)) STORED,
+    Item STRUCT<Name STRING(10), Count INT64>,
+    CONSTRAINT CK_T CHECK (Total > 0),
+) PRIMARY KEY (Id DESC), ROW DELETION POLICY (OLDER_THAN(Created, INTERVAL 1 DAY));`},
+			want: spanner.Schemas{
+				{
+					Name: "T",
+					Type: "BASE TABLE",
+					Columns: []spanner.Column{
+						{Name: "Id", Type: "STRING(MAX)", Nullable: false},
+						{Name: "Tags", Type: "ARRAY<STRING(20)>", Nullable: false},
+						{Name: "Embedding", Type: "ARRAY<FLOAT32>(vector_length=>128)", Nullable: true},
+						{Name: "Amount", Type: "NUMERIC", Nullable: true},
+						{Name: "Total", Type: "INT64", Nullable: true},
+						{Name: "Item", Type: "STRUCT<Name STRING(10), Count INT64>", Nullable: true},
+					},
+					PrimaryKey: []string{"Id"},
+				},
+			},
+		},
+		{
+			name: "alter and drop",
+			ddls: []string{`
+CREATE TABLE P (Id INT64 NOT NULL, Old BOOL) PRIMARY KEY (Id);
+CREATE TABLE C (Id INT64 NOT NULL, Sub INT64 NOT NULL, Ref INT64) PRIMARY KEY (Id, Sub), INTERLEAVE IN PARENT P ON DELETE CASCADE;
+CREATE TABLE X (Id INT64 NOT NULL) PRIMARY KEY (Id);`, `
+-- comment
+ALTER TABLE P ADD COLUMN Name STRING(50);
+ALTER TABLE P DROP COLUMN Old;
+ALTER TABLE P ALTER COLUMN Name STRING(100) NOT NULL;
+ALTER TABLE P ALTER COLUMN Name SET OPTIONS (allow_commit_timestamp = null);
+ALTER TABLE C ADD FOREIGN KEY (Ref) REFERENCES P (Id);
+ALTER TABLE C ADD CONSTRAINT FK_C_X FOREIGN KEY (Ref) REFERENCES X (Id) ON DELETE CASCADE;
+ALTER TABLE C DROP CONSTRAINT FK_C_X;
+CREATE NULL_FILTERED INDEX IDX_C_Ref ON C (Ref DESC) STORING (Sub), INTERLEAVE IN P;
+CREATE UNIQUE INDEX UQ_P_Name ON P (Name);
+CREATE INDEX IDX_P_Name ON P (Name);
+DROP INDEX IDX_P_Name;
+DROP TABLE X;
+CREATE VIEW V SQL SECURITY INVOKER AS SELECT Id FROM P;`},
+			want: spanner.Schemas{
+				{
+					Name:   "C",
+					Type:   "BASE TABLE",
+					Parent: "P",
+					Columns: []spanner.Column{
+						{Name: "Id", Type: "INT64", Nullable: false},
+						{Name: "Sub", Type: "INT64", Nullable: false},
+						{Name: "Ref", Type: "INT64", Nullable: true},
+					},
+					PrimaryKey: []string{"Id", "Sub"},
+					ForeignKeys: []spanner.ForeignKey{
+						{Key: []string{"Ref"}, Reference: spanner.ForeignKeyReference{Table: "P", Key: []string{"Id"}}},
+					},
+					Indexes: []spanner.Index{
+						{Name: "IDX_C_Ref", Key: []spanner.IndexKeyElem{{Name: "Ref", Desc: true}}},
+					},
+				},
+				{
+					Name: "P",
+					Type: "BASE TABLE",
+					Columns: []spanner.Column{
+						{Name: "Id", Type: "INT64", Nullable: false},
+						{Name: "Name", Type: "STRING(100)", Nullable: false},
+					},
+					PrimaryKey: []string{"Id"},
+					Indexes: []spanner.Index{
+						{Name: "UQ_P_Name", Unique: true, Key: []spanner.IndexKeyElem{{Name: "Name"}}},
+					},
+				},
+			},
+		},
+		{
+			name: "if exists and if not exists",
+			ddls: []string{`
+CREATE TABLE A (Id INT64 NOT NULL, Name STRING(10)) PRIMARY KEY (Id);
+CREATE TABLE IF NOT EXISTS A (Id STRING(MAX) NOT NULL) PRIMARY KEY (Id);
+ALTER TABLE A ADD COLUMN IF NOT EXISTS Name BOOL;
+CREATE INDEX IDX_A_Name ON A (Name);
+CREATE UNIQUE INDEX IF NOT EXISTS IDX_A_Name ON A (Id);
+DROP TABLE IF EXISTS B;
+DROP INDEX IF EXISTS IDX_B;`},
+			want: spanner.Schemas{
+				{
+					Name: "A",
+					Type: "BASE TABLE",
+					Columns: []spanner.Column{
+						{Name: "Id", Type: "INT64", Nullable: false},
+						{Name: "Name", Type: "STRING(10)", Nullable: true},
+					},
+					PrimaryKey: []string{"Id"},
+					Indexes: []spanner.Index{
+						{Name: "IDX_A_Name", Key: []spanner.IndexKeyElem{{Name: "Name"}}},
+					},
+				},
+			},
+		},
+		{
+			name:    "duplicated table",
+			ddls:    []string{`CREATE TABLE A (Id INT64) PRIMARY KEY (Id); CREATE TABLE A (Id INT64) PRIMARY KEY (Id);`},
+			wantErr: true,
+		},
+		{
+			name:    "duplicated index",
+			ddls:    []string{`CREATE TABLE A (Id INT64) PRIMARY KEY (Id); CREATE INDEX IDX ON A (Id); CREATE INDEX IDX ON A (Id);`},
+			wantErr: true,
+		},
+		{
+			name:    "duplicated column",
+			ddls:    []string{`CREATE TABLE A (Id INT64) PRIMARY KEY (Id); ALTER TABLE A ADD COLUMN Id INT64;`},
+			wantErr: true,
+		},
+		{
+			name:    "drop unknown table",
+			ddls:    []string{`DROP TABLE A;`},
+			wantErr: true,
+		},
+		{
+			name:    "unknown table",
+			ddls:    []string{`CREATE INDEX IDX ON A (Id);`},
+			wantErr: true,
+		},
+		{
+			name:    "missing primary key",
+			ddls:    []string{`CREATE TABLE A (Id INT64);`},
+			wantErr: true,
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			got, err := spanner.ParseDDL(testcase.ddls...)
+			if testcase.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, testcase.want, got)
+		})
+	}
+}
+
+func TestGenerateWithDDL(t *testing.T) {
+	for _, testcase := range generateWithSchemaTestcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			var ddlFiles []string
+			for i, ddl := range testcase.ddls {
+				path := filepath.Join(t.TempDir(), fmt.Sprintf(`ddl_%d.sql`, i))
+				require.Nil(t, os.WriteFile(path, []byte(ddl), 0644))
+				ddlFiles = append(ddlFiles, path)
+			}
+
+			var got spanner.Schemas
+			err := spanner.GenerateWithDDL(
+				context.Background(),
+				ddlFiles,
+				testcase.in,
+				func(_ *files.Writer, schemas spanner.Schemas) error {
+					got = schemas
+					return nil
+				})
+			require.Nil(t, err)
+			equalSchema(t, testcase.want, got)
+		})
+	}
+
+	t.Run("missing table", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), `ddl.sql`)
+		require.Nil(t, os.WriteFile(path, []byte("CREATE TABLE A (PK INT64 NOT NULL) PRIMARY KEY (PK)"), 0644))
+		err := spanner.GenerateWithDDL(context.Background(), []string{path}, []string{"A", "B"}, func(_ *files.Writer, _ spanner.Schemas) error { return nil })
+		require.NotNil(t, err)
+	})
+}
@@ -7,7 +7,7 @@ import (
 	"reflect"
 )
 
-func QueryRows[RecordStruct any](ctx context.Context, q queryer, stmt spanner.Statement) (records []RecordStruct, err error) {
+func QueryRows[RecordStruct any](ctx context.Context, q Queryer, stmt spanner.Statement) (records []RecordStruct, err error) {
 	{
 		var rs RecordStruct
 		rv := reflect.ValueOf(rs)
@@ -2,26 +2,144 @@ package spanner
 
 import (
 	"cloud.google.com/go/spanner"
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
 	"context"
 	"errors"
 	"fmt"
+	"github.com/Jumpaku/schenerate/schema"
 	"google.golang.org/api/iterator"
 )
 
-type queryer struct {
+// Queryer queries a Spanner database, which implements schema.Queryer.
+type Queryer struct {
 	client *spanner.Client
 }
 
-func Open(ctx context.Context, project, instance, database string) (queryer, error) {
+func Open(ctx context.Context, project, instance, database string) (Queryer, error) {
 	client, err := spanner.NewClient(ctx, fmt.Sprintf("projects/%s/instances/%s/databases/%s", project, instance, database))
 	if err != nil {
-		return queryer{}, fmt.Errorf("failed to create spanner client: %w", err)
+		return Queryer{}, fmt.Errorf("failed to create spanner client: %w", err)
 	}
-	return queryer{client: client}, nil
+	return Queryer{client: client}, nil
 }
 
-func (q queryer) Close() {
+func (q Queryer) Close() error {
 	q.client.Close()
+	return nil
+}
+
+var _ schema.Queryer = Queryer{}
+
+func (q Queryer) ListTables(ctx context.Context) ([]schema.Table, error) {
+	return listTables(ctx, q)
+}
+
+// SelectTables returns the names of the tables selected by the selector qualified by their named schemas, which is used by introspect.SelectTables.
+func (q Queryer) SelectTables(ctx context.Context, selector schema.Selector) ([]string, error) {
+	return SelectTables(ctx, q, selector)
+}
+
+// ListReferences returns the tables referenced by each table by a single query, which is used by introspect.ListSchemas.
+func (q Queryer) ListReferences(ctx context.Context) (map[string][]string, error) {
+	return listReferences(ctx, q)
+}
+
+func (q Queryer) ListSchemas(ctx context.Context, tables []string) (schema.Schemas, error) {
+	schemas, err := ListSchemas(ctx, q, tables)
+	if err != nil {
+		return nil, err
+	}
+	return schemas.Unified(), nil
+}
+
+// QueryRows executes the query statement whose parameters are referenced as @p1, @p2, ... in order.
+func (q Queryer) QueryRows(ctx context.Context, stmt string, params []any) (records []schema.Row, err error) {
+	st := spanner.NewStatement(stmt)
+	for i, p := range params {
+		st.Params[fmt.Sprintf("p%d", i+1)] = p
+	}
+
+	tx := q.client.ReadOnlyTransaction()
+	defer tx.Close()
+
+	rows := tx.Query(ctx, st)
+	defer rows.Stop()
+	for {
+		row, err := rows.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return nil, fmt.Errorf("failed to query: %w", err)
+		}
+		record := schema.Row{}
+		for i, column := range row.ColumnNames() {
+			var col spanner.GenericColumnValue
+			if err := row.Column(i, &col); err != nil {
+				return nil, fmt.Errorf("failed to scan: %w", err)
+			}
+			v, err := decodeValue(col)
+			if err != nil {
+				return nil, fmt.Errorf("failed to scan column %q: %w", column, err)
+			}
+			record[column] = v
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func decodeValue(col spanner.GenericColumnValue) (any, error) {
+	decode := func(v interface{ IsNull() bool }, get func() any) (any, error) {
+		if err := col.Decode(v); err != nil {
+			return nil, err
+		}
+		if v.IsNull() {
+			return nil, nil
+		}
+		return get(), nil
+	}
+	switch col.Type.GetCode() {
+	case spannerpb.TypeCode_BOOL:
+		var v spanner.NullBool
+		return decode(&v, func() any { return v.Bool })
+	case spannerpb.TypeCode_INT64, spannerpb.TypeCode_ENUM:
+		var v spanner.NullInt64
+		return decode(&v, func() any { return v.Int64 })
+	case spannerpb.TypeCode_FLOAT32:
+		var v spanner.NullFloat32
+		return decode(&v, func() any { return v.Float32 })
+	case spannerpb.TypeCode_FLOAT64:
+		var v spanner.NullFloat64
+		return decode(&v, func() any { return v.Float64 })
+	case spannerpb.TypeCode_STRING:
+		var v spanner.NullString
+		return decode(&v, func() any { return v.StringVal })
+	case spannerpb.TypeCode_BYTES, spannerpb.TypeCode_PROTO:
+		var v []byte
+		if err := col.Decode(&v); err != nil {
+			return nil, err
+		}
+		if v == nil {
+			return nil, nil
+		}
+		return v, nil
+	case spannerpb.TypeCode_TIMESTAMP:
+		var v spanner.NullTime
+		return decode(&v, func() any { return v.Time })
+	case spannerpb.TypeCode_DATE:
+		var v spanner.NullDate
+		return decode(&v, func() any { return v.Date })
+	case spannerpb.TypeCode_NUMERIC:
+		var v spanner.NullNumeric
+		return decode(&v, func() any { return v.Numeric })
+	case spannerpb.TypeCode_JSON:
+		var v spanner.NullJSON
+		return decode(&v, func() any { return v.Value })
+	default:
+		return col.Value.AsInterface(), nil
+	}
 }
 
 func query[Record any](ctx context.Context, tx *spanner.ReadOnlyTransaction, stmt spanner.Statement) (records []Record, err error) {
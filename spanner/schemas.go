@@ -29,39 +29,39 @@ func (s Schemas) BuildGraph() graph.Graph[Schema] {
 }
 
 type Schema struct {
-	Name        string
-	Type        string
-	Parent      string
-	Columns     []Column
-	PrimaryKey  []string
-	ForeignKeys []ForeignKey
-	Indexes     []Index
+	Name        string       `json:"name" yaml:"name"`
+	Type        string       `json:"type" yaml:"type"`
+	Parent      string       `json:"parent" yaml:"parent"`
+	Columns     []Column     `json:"columns" yaml:"columns"`
+	PrimaryKey  []string     `json:"primaryKey" yaml:"primaryKey"`
+	ForeignKeys []ForeignKey `json:"foreignKeys" yaml:"foreignKeys"`
+	Indexes     []Index      `json:"indexes" yaml:"indexes"`
 }
 
 type Column struct {
-	Name     string
-	Type     string
-	Nullable bool
+	Name     string `json:"name" yaml:"name"`
+	Type     string `json:"type" yaml:"type"`
+	Nullable bool   `json:"nullable" yaml:"nullable"`
 }
 
 type ForeignKey struct {
-	Name      string
-	Key       []string
-	Reference ForeignKeyReference
+	Name      string              `json:"name" yaml:"name"`
+	Key       []string            `json:"key" yaml:"key"`
+	Reference ForeignKeyReference `json:"reference" yaml:"reference"`
 }
 
 type ForeignKeyReference struct {
-	Table string
-	Key   []string
+	Table string   `json:"table" yaml:"table"`
+	Key   []string `json:"key" yaml:"key"`
 }
 
 type Index struct {
-	Name   string
-	Unique bool
-	Key    []IndexKeyElem
+	Name   string         `json:"name" yaml:"name"`
+	Unique bool           `json:"unique" yaml:"unique"`
+	Key    []IndexKeyElem `json:"key" yaml:"key"`
 }
 
 type IndexKeyElem struct {
-	Name string
-	Desc bool
+	Name string `json:"name" yaml:"name"`
+	Desc bool   `json:"desc" yaml:"desc"`
 }
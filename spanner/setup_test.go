@@ -4,9 +4,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"github.com/Jumpaku/sqanner/tokenize"
 	"github.com/samber/lo"
-	"strings"
 	"testing"
 
 	spanner_admin "cloud.google.com/go/spanner/admin/database/apiv1"
@@ -16,12 +14,12 @@ import (
 var spannerInstance = flag.String("instance", "", "-instance=<spanner instance>")
 var spannerProject = flag.String("project", "", "-project=<GCP project>")
 
-func Setup(t *testing.T, database string, ddls []string) (q queryer, teardown func()) {
+func Setup(t *testing.T, database string, ddls []string) (q Queryer, teardown func()) {
 	t.Helper()
 
 	if *spannerProject == "" || *spannerInstance == "" {
 		t.Skip(`spanner project and instance are required`)
-		return queryer{}, nil
+		return Queryer{}, nil
 	}
 
 	project := *spannerProject
@@ -50,25 +48,12 @@ func Setup(t *testing.T, database string, ddls []string) (q queryer, teardown fu
 		}
 		{
 			ddls = lo.FlatMap(ddls, func(s string, _ int) []string {
-				tokens, err := tokenize.Tokenize([]rune(s))
+				ddls, err := splitStatements(s)
 				if err != nil {
 					t.Fatalf(`fail to tokenize: %v`, err)
 				}
-				ddls := []string{""}
-				for _, token := range tokens {
-					switch {
-					case token.Kind == tokenize.TokenSpecialChar && string(token.Content) == ";":
-						ddls = append(ddls, "")
-					case token.Kind == tokenize.TokenComment || token.Kind == tokenize.TokenSpace:
-						ddls[len(ddls)-1] += " "
-					default:
-						ddls[len(ddls)-1] += string(token.Content)
-					}
-				}
 				return ddls
 			})
-			ddls = lo.Map(ddls, func(s string, _ int) string { return strings.TrimSpace(s) })
-			ddls = lo.Filter(ddls, func(s string, _ int) bool { return s != "" })
 			if len(ddls) > 0 {
 				op, err := c.UpdateDatabaseDdl(ctx, &spanner_adminpb.UpdateDatabaseDdlRequest{
 					Database:   dataSource,
@@ -0,0 +1,100 @@
+package spanner
+
+import (
+	"fmt"
+	"github.com/Jumpaku/schenerate/internal/slice"
+	"github.com/Jumpaku/schenerate/schema"
+)
+
+// Unified converts the schemas into the dialect-neutral schemas.
+func (s Schemas) Unified() schema.Schemas {
+	return slice.Map(s, func(s Schema) schema.Schema {
+		return schema.Schema{
+			Dialect: schema.DialectSpanner,
+			Name:    s.Name,
+			Type:    s.Type,
+			Parent:  s.Parent,
+			Columns: slice.Map(s.Columns, func(c Column) schema.Column {
+				return schema.Column{Name: c.Name, Type: c.Type, Nullable: c.Nullable}
+			}),
+			PrimaryKey: s.PrimaryKey,
+			ForeignKeys: slice.Map(s.ForeignKeys, func(fk ForeignKey) schema.ForeignKey {
+				return schema.ForeignKey{
+					Name: fk.Name,
+					Key:  fk.Key,
+					Reference: schema.ForeignKeyReference{
+						Table: fk.Reference.Table,
+						Key:   fk.Reference.Key,
+					},
+				}
+			}),
+			Indexes: slice.Map(s.Indexes, func(idx Index) schema.Index {
+				return schema.Index{
+					Name:   idx.Name,
+					Unique: idx.Unique,
+					Key: slice.Map(idx.Key, func(k IndexKeyElem) schema.IndexKeyElem {
+						return schema.IndexKeyElem{Name: k.Name, Desc: k.Desc}
+					}),
+				}
+			}),
+		}
+	})
+}
+
+// FromUnified converts the dialect-neutral schemas into the schemas of Spanner.
+func FromUnified(schemas schema.Schemas) (Schemas, error) {
+	if schemas == nil {
+		return nil, nil
+	}
+	result := Schemas{}
+	for _, s := range schemas {
+		if s.Dialect != schema.DialectSpanner {
+			return nil, fmt.Errorf(`fail to convert schema of %s: dialect %q is not %q`, s.Name, s.Dialect, schema.DialectSpanner)
+		}
+		if s.Schema != "" {
+			return nil, fmt.Errorf(`fail to convert schema of %s: schema %q is not supported`, s.Name, s.Schema)
+		}
+		if len(s.UniqueKeys) > 0 {
+			return nil, fmt.Errorf(`fail to convert schema of %s: unique keys are not supported`, s.Name)
+		}
+		for _, fk := range s.ForeignKeys {
+			if fk.Reference.Schema != "" {
+				return nil, fmt.Errorf(`fail to convert foreign key %s of %s: schema of reference is not supported`, fk.Name, s.Name)
+			}
+		}
+		for _, idx := range s.Indexes {
+			if idx.Origin != "" {
+				return nil, fmt.Errorf(`fail to convert index %s of %s: origin is not supported`, idx.Name, s.Name)
+			}
+		}
+		result = append(result, Schema{
+			Name:   s.Name,
+			Type:   s.Type,
+			Parent: s.Parent,
+			Columns: slice.Map(s.Columns, func(c schema.Column) Column {
+				return Column{Name: c.Name, Type: c.Type, Nullable: c.Nullable}
+			}),
+			PrimaryKey: s.PrimaryKey,
+			ForeignKeys: slice.Map(s.ForeignKeys, func(fk schema.ForeignKey) ForeignKey {
+				return ForeignKey{
+					Name: fk.Name,
+					Key:  fk.Key,
+					Reference: ForeignKeyReference{
+						Table: fk.Reference.Table,
+						Key:   fk.Reference.Key,
+					},
+				}
+			}),
+			Indexes: slice.Map(s.Indexes, func(idx schema.Index) Index {
+				return Index{
+					Name:   idx.Name,
+					Unique: idx.Unique,
+					Key: slice.Map(idx.Key, func(k schema.IndexKeyElem) IndexKeyElem {
+						return IndexKeyElem{Name: k.Name, Desc: k.Desc}
+					}),
+				}
+			}),
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,65 @@
+package spanner
+
+import (
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSchemas_Unified(t *testing.T) {
+	sut := Schemas{
+		{
+			Name:       "B_1",
+			Type:       "BASE TABLE",
+			Columns:    []Column{{Name: "PK_11", Type: "INT64"}},
+			PrimaryKey: []string{"PK_11"},
+			Indexes:    []Index{{Name: "IDX", Unique: true, Key: []IndexKeyElem{{Name: "PK_11", Desc: true}}}},
+		},
+		{
+			Name:       "B_2",
+			Type:       "BASE TABLE",
+			Parent:     "B_1",
+			Columns:    []Column{{Name: "PK_11", Type: "INT64"}, {Name: "PK_21", Type: "STRING(MAX)", Nullable: true}},
+			PrimaryKey: []string{"PK_11", "PK_21"},
+			ForeignKeys: []ForeignKey{
+				{Name: "FK", Key: []string{"PK_11"}, Reference: ForeignKeyReference{Table: "B_1", Key: []string{"PK_11"}}},
+			},
+		},
+	}
+
+	got := sut.Unified()
+
+	require.Equal(t, schema.Schemas{
+		{
+			Dialect:    schema.DialectSpanner,
+			Name:       "B_1",
+			Type:       "BASE TABLE",
+			Columns:    []schema.Column{{Name: "PK_11", Type: "INT64"}},
+			PrimaryKey: []string{"PK_11"},
+			Indexes:    []schema.Index{{Name: "IDX", Unique: true, Key: []schema.IndexKeyElem{{Name: "PK_11", Desc: true}}}},
+		},
+		{
+			Dialect:    schema.DialectSpanner,
+			Name:       "B_2",
+			Type:       "BASE TABLE",
+			Parent:     "B_1",
+			Columns:    []schema.Column{{Name: "PK_11", Type: "INT64"}, {Name: "PK_21", Type: "STRING(MAX)", Nullable: true}},
+			PrimaryKey: []string{"PK_11", "PK_21"},
+			ForeignKeys: []schema.ForeignKey{
+				{Name: "FK", Key: []string{"PK_11"}, Reference: schema.ForeignKeyReference{Table: "B_1", Key: []string{"PK_11"}}},
+			},
+		},
+	}, got)
+	require.Equal(t, [][]int{{}, {0}}, [][]int{got.BuildGraph().References(0), got.BuildGraph().References(1)})
+
+	back, err := FromUnified(got)
+	require.Nil(t, err)
+	require.Equal(t, sut, back)
+}
+
+func TestFromUnified_Error(t *testing.T) {
+	_, err := FromUnified(schema.Schemas{{Dialect: schema.DialectSQLite3, Name: "A"}})
+	require.Error(t, err)
+	_, err = FromUnified(schema.Schemas{{Dialect: schema.DialectSpanner, Name: "A", UniqueKeys: []schema.UniqueKey{{Name: "U"}}}})
+	require.Error(t, err)
+}
@@ -0,0 +1,99 @@
+package sqlite3
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Affinity is a type affinity of SQLite3, which is determined from the declared type of a column.
+type Affinity string
+
+const (
+	AffinityInteger Affinity = "INTEGER"
+	AffinityText    Affinity = "TEXT"
+	AffinityBlob    Affinity = "BLOB"
+	AffinityReal    Affinity = "REAL"
+	AffinityNumeric Affinity = "NUMERIC"
+)
+
+// ColumnType is a parsed representation of a declared column type such as VARCHAR(50) and DECIMAL(10, 2).
+type ColumnType struct {
+	// Base is the declared type name without parameters in upper case, which is empty if the type is not declared.
+	Base string
+	// Length is the length of character and binary types, which is 0 if not specified or Max is true.
+	Length int64
+	// Max is true if the length is MAX, e.g. STRING(MAX) and VARCHAR(MAX).
+	Max bool
+	// Precision and Scale are the parameters of the other types, which are 0 if not specified.
+	Precision int64
+	Scale     int64
+	// Affinity is the type affinity determined by the rules of SQLite3.
+	Affinity Affinity
+}
+
+// ParseType parses the type of the column.
+func (c Column) ParseType() (ColumnType, error) {
+	return ParseColumnType(c.Type)
+}
+
+// ParseColumnType parses a declared column type of SQLite3.
+func ParseColumnType(s string) (ColumnType, error) {
+	t, err := parseColumnType(strings.ToUpper(strings.Join(strings.Fields(s), " ")))
+	if err != nil {
+		return ColumnType{}, fmt.Errorf(`fail to parse column type %q: %w`, s, err)
+	}
+	return t, nil
+}
+
+func parseColumnType(s string) (ColumnType, error) {
+	var params []int64
+	if begin := strings.Index(s, "("); begin >= 0 {
+		end := strings.LastIndex(s, ")")
+		if end < begin {
+			return ColumnType{}, fmt.Errorf(`unclosed '('`)
+		}
+		if base := strings.TrimSpace(s[:begin]); strings.TrimSpace(s[begin+1:end]) == "MAX" {
+			return ColumnType{Base: base, Max: true, Affinity: affinity(base)}, nil
+		}
+		for _, p := range strings.Split(s[begin+1:end], ",") {
+			v, err := strconv.ParseInt(strings.TrimSpace(p), 10, 64)
+			if err != nil {
+				return ColumnType{}, fmt.Errorf(`invalid parameter %q: %w`, p, err)
+			}
+			params = append(params, v)
+		}
+		s = strings.TrimSpace(s[:begin])
+	}
+
+	t := ColumnType{Base: s, Affinity: affinity(s)}
+	switch {
+	case len(params) == 0:
+	case len(params) > 2:
+		return ColumnType{}, fmt.Errorf(`too many parameters for %s`, t.Base)
+	case len(params) == 1 && (t.Affinity == AffinityText || t.Affinity == AffinityBlob || strings.Contains(s, "BINARY") || strings.Contains(s, "BYTES") || strings.Contains(s, "STRING")):
+		t.Length = params[0]
+	default:
+		t.Precision = params[0]
+		if len(params) == 2 {
+			t.Scale = params[1]
+		}
+	}
+	return t, nil
+}
+
+// affinity determines the type affinity by the rules in https://www.sqlite.org/datatype3.html#determination_of_column_affinity.
+func affinity(declared string) Affinity {
+	switch {
+	case strings.Contains(declared, "INT"):
+		return AffinityInteger
+	case strings.Contains(declared, "CHAR"), strings.Contains(declared, "CLOB"), strings.Contains(declared, "TEXT"):
+		return AffinityText
+	case declared == "", strings.Contains(declared, "BLOB"):
+		return AffinityBlob
+	case strings.Contains(declared, "REAL"), strings.Contains(declared, "FLOA"), strings.Contains(declared, "DOUB"):
+		return AffinityReal
+	default:
+		return AffinityNumeric
+	}
+}
@@ -0,0 +1,42 @@
+package sqlite3_test
+
+import (
+	"github.com/Jumpaku/schenerate/sqlite3"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseColumnType(t *testing.T) {
+	testcases := []struct {
+		in      string
+		want    sqlite3.ColumnType
+		wantErr bool
+	}{
+		{in: "", want: sqlite3.ColumnType{Affinity: sqlite3.AffinityBlob}},
+		{in: "INTEGER", want: sqlite3.ColumnType{Base: "INTEGER", Affinity: sqlite3.AffinityInteger}},
+		{in: "int64", want: sqlite3.ColumnType{Base: "INT64", Affinity: sqlite3.AffinityInteger}},
+		{in: "VARCHAR(255)", want: sqlite3.ColumnType{Base: "VARCHAR", Length: 255, Affinity: sqlite3.AffinityText}},
+		{in: "STRING(50)", want: sqlite3.ColumnType{Base: "STRING", Length: 50, Affinity: sqlite3.AffinityNumeric}},
+		{in: "STRING(MAX)", want: sqlite3.ColumnType{Base: "STRING", Max: true, Affinity: sqlite3.AffinityNumeric}},
+		{in: "varchar(max)", want: sqlite3.ColumnType{Base: "VARCHAR", Max: true, Affinity: sqlite3.AffinityText}},
+		{in: "BYTES(50)", want: sqlite3.ColumnType{Base: "BYTES", Length: 50, Affinity: sqlite3.AffinityNumeric}},
+		{in: "DECIMAL(10, 5)", want: sqlite3.ColumnType{Base: "DECIMAL", Precision: 10, Scale: 5, Affinity: sqlite3.AffinityNumeric}},
+		{in: "double precision", want: sqlite3.ColumnType{Base: "DOUBLE PRECISION", Affinity: sqlite3.AffinityReal}},
+		{in: "FLOATING POINT", want: sqlite3.ColumnType{Base: "FLOATING POINT", Affinity: sqlite3.AffinityInteger}},
+		{in: "BLOB", want: sqlite3.ColumnType{Base: "BLOB", Affinity: sqlite3.AffinityBlob}},
+		{in: "VARCHAR(", wantErr: true},
+		{in: "VARCHAR(a)", wantErr: true},
+		{in: "DECIMAL(1,2,3)", wantErr: true},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.in, func(t *testing.T) {
+			got, err := sqlite3.ParseColumnType(testcase.in)
+			if testcase.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, testcase.want, got)
+		})
+	}
+}
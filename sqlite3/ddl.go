@@ -0,0 +1,57 @@
+package sqlite3
+
+import (
+	"github.com/Jumpaku/schenerate/ddl"
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/samber/lo"
+	"slices"
+	"strings"
+)
+
+// DDL returns the statements creating the tables of the schemas, which are rendered by ddl.Render.
+// Foreign keys are declared in the reverse order of ForeignKeys, because pragma_foreign_key_list lists them in the reverse order of declaration.
+// Primary keys are declared after the unique keys whose indexes precede them, so that the indexes are named sqlite_autoindex_<table>_<N> in the same order.
+func (s Schemas) DDL() ([]string, error) {
+	unified := s.Unified()
+	precedings := map[string]int{}
+	for i := range unified {
+		unified[i].ForeignKeys = slices.Clone(unified[i].ForeignKeys)
+		slices.Reverse(unified[i].ForeignKeys)
+		precedings["CREATE TABLE "+ddl.TableName(schema.DialectSQLite3, unified[i])+" ("] = uniqueKeysPrecedingPrimaryKey(s[i])
+	}
+	stmts, err := ddl.Render(unified)
+	if err != nil {
+		return nil, err
+	}
+	for i, stmt := range stmts {
+		header, _, _ := strings.Cut(stmt, "\n")
+		if n := precedings[header]; n > 0 {
+			stmts[i] = declarePrimaryKeyAfter(stmt, n)
+		}
+	}
+	return stmts, nil
+}
+
+// uniqueKeysPrecedingPrimaryKey returns the number of the indexes of the unique keys numbered before that of the primary key.
+func uniqueKeysPrecedingPrimaryKey(s Schema) int {
+	pk, ok := lo.Find(s.Indexes, func(idx Index) bool { return idx.Origin == IndexOriginPrimaryKey })
+	if !ok {
+		return 0
+	}
+	return lo.CountBy(s.Indexes, func(idx Index) bool {
+		return idx.Origin == IndexOriginUniqueConstraint && autoindexNumber(idx.Name) < autoindexNumber(pk.Name)
+	})
+}
+
+// declarePrimaryKeyAfter moves the PRIMARY KEY definition of the CREATE TABLE statement rendered by ddl.CreateTable after the following n definitions of the unique keys.
+func declarePrimaryKeyAfter(stmt string, n int) string {
+	lines := strings.Split(stmt, "\n")
+	defs := lo.Map(lines[1:len(lines)-1], func(line string, _ int) string { return strings.TrimSuffix(line, ",") })
+	p := slices.IndexFunc(defs, func(def string) bool { return strings.HasPrefix(def, "  PRIMARY KEY ") })
+	if p < 0 || p+n >= len(defs) {
+		return stmt
+	}
+	pk := defs[p]
+	defs = slices.Insert(slices.Delete(defs, p, p+1), p+n, pk)
+	return lines[0] + "\n" + strings.Join(defs, ",\n") + "\n" + lines[len(lines)-1]
+}
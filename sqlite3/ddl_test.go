@@ -0,0 +1,44 @@
+package sqlite3_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/Jumpaku/schenerate/sqlite3"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestSchemas_DDL(t *testing.T) {
+	testcases := []struct {
+		name string
+		ddls []string
+		in   []string
+	}{
+		{name: "all types", ddls: []string{generate_ddl00AllTypes}, in: []string{"A"}},
+		{name: "foreign keys", ddls: []string{generate_ddl02ForeignKeys}, in: []string{"C_1", "C_2", "C_3", "C_4", "C_5"}},
+		{name: "foreign loop 1", ddls: []string{generate_ddl03ForeignLoop1}, in: []string{"D_1"}},
+		{name: "foreign loop 2", ddls: []string{generate_ddl04ForeignLoop2}, in: []string{"E_1", "E_2"}},
+		{name: "foreign loop 3", ddls: []string{generate_ddl05ForeignLoop3}, in: []string{"F_1", "F_2", "F_3"}},
+		{name: "unique keys index", ddls: []string{generate_ddl06UniqueKeysIndex}, in: []string{"G"}},
+		{name: "unique keys constraint", ddls: []string{generate_ddl07UniqueKeysConstraint}, in: []string{"H"}},
+		{name: "unique keys column", ddls: []string{generate_ddl08UniqueKeysColumn}, in: []string{"I"}},
+	}
+	for number, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			q, teardown := sqlite3.Setup(t, fmt.Sprintf(`test_ddl_%d_%d.sqlite`, number, time.Now().Unix()), testcase.ddls)
+			defer teardown()
+			want, err := sqlite3.ListSchemas(context.Background(), q, testcase.in)
+			require.Nil(t, err)
+
+			stmts, err := want.DDL()
+			require.Nil(t, err)
+
+			q2, teardown2 := sqlite3.Setup(t, fmt.Sprintf(`test_ddl_rendered_%d_%d.sqlite`, number, time.Now().Unix()), stmts)
+			defer teardown2()
+			got, err := sqlite3.ListSchemas(context.Background(), q2, testcase.in)
+			require.Nil(t, err)
+			require.Equal(t, want, got)
+		})
+	}
+}
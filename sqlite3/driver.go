@@ -0,0 +1,15 @@
+package sqlite3
+
+import (
+	"context"
+	"github.com/Jumpaku/schenerate/introspect"
+)
+
+// DriverName is the name of the driver registered to introspect, whose data source is a DSN of github.com/mattn/go-sqlite3.
+const DriverName = "sqlite3"
+
+func init() {
+	introspect.Register(DriverName, func(ctx context.Context, dataSource string) (introspect.Introspector, error) {
+		return Open(dataSource)
+	})
+}
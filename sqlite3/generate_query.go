@@ -8,7 +8,7 @@ import (
 
 type GeneratorWithQuery[RecordStruct any] func(out *files.Writer, rows []RecordStruct) error
 
-func GenerateWithQuery[RecordStruct any](ctx context.Context, q queryer, stmt string, params []any, generator GeneratorWithQuery[RecordStruct]) error {
+func GenerateWithQuery[RecordStruct any](ctx context.Context, q Queryer, stmt string, params []any, generator GeneratorWithQuery[RecordStruct]) error {
 	rows, err := QueryRows[RecordStruct](ctx, q, stmt, params)
 	if err != nil {
 		return fmt.Errorf(`fail to query rows: %w`, err)
@@ -8,7 +8,53 @@ import (
 
 type GeneratorWithSchema func(out *files.Writer, schemas Schemas) error
 
-func GenerateWithSchema(ctx context.Context, q queryer, tables []string, generator GeneratorWithSchema) error {
+func GenerateWithSchema(ctx context.Context, q Queryer, tables []string, generator GeneratorWithSchema) error {
+	schemas, err := ListSchemas(ctx, q, tables)
+	if err != nil {
+		return fmt.Errorf(`fail to list schemas: %w`, err)
+	}
+
+	w := &files.Writer{}
+	if err := generator(w, schemas); err != nil {
+		return err
+	}
+
+	if err := w.SaveAll(); err != nil {
+		return fmt.Errorf(`fail to save files writer: %w`, err)
+	}
+	return nil
+}
+
+// GenerateWithSnapshot calls generator with the schemas loaded from the snapshot file instead of querying a database.
+func GenerateWithSnapshot(path string, generator GeneratorWithSchema) error {
+	schemas, err := LoadSnapshot(path)
+	if err != nil {
+		return fmt.Errorf(`fail to load snapshot: %w`, err)
+	}
+
+	w := &files.Writer{}
+	if err := generator(w, schemas); err != nil {
+		return err
+	}
+
+	if err := w.SaveAll(); err != nil {
+		return fmt.Errorf(`fail to save files writer: %w`, err)
+	}
+	return nil
+}
+
+// GenerateWithDDL calls generator with the schemas of the tables declared by the DDL files instead of querying a database.
+func GenerateWithDDL(ctx context.Context, ddlFiles []string, tables []string, generator GeneratorWithSchema) error {
+	ddls, err := files.ReadAll(ddlFiles...)
+	if err != nil {
+		return fmt.Errorf(`fail to read DDL files: %w`, err)
+	}
+	q, err := OpenDDL(ddls...)
+	if err != nil {
+		return fmt.Errorf(`fail to open DDL: %w`, err)
+	}
+	defer q.Close()
+
 	schemas, err := ListSchemas(ctx, q, tables)
 	if err != nil {
 		return fmt.Errorf(`fail to list schemas: %w`, err)
@@ -0,0 +1,45 @@
+package sqlite3
+
+import (
+	"github.com/Jumpaku/schenerate/gotype"
+	"strings"
+)
+
+// NewGoTypeMapper returns a mapper from columns to Go types with the defaults of SQLite3.
+func NewGoTypeMapper(style gotype.NullStyle) *gotype.Mapper {
+	return gotype.NewMapper(DefaultGoType, style)
+}
+
+// DefaultGoType returns the default mapping of the declared SQLite3 column type.
+// Types of booleans, dates, strings and bytes are determined by their names, and the others are determined by their affinities.
+// NullStyleDriver is the same as NullStyleDatabaseSQL because github.com/mattn/go-sqlite3 provides no nullable types.
+func DefaultGoType(columnType string, style gotype.NullStyle) (gotype.Mapping, bool) {
+	t, err := ParseColumnType(columnType)
+	if err != nil {
+		return gotype.Mapping{}, false
+	}
+	switch {
+	case strings.Contains(t.Base, "BOOL"):
+		return gotype.Nullable(style, gotype.Type{Name: "bool"}, sqlGoType("NullBool"), gotype.Type{}), true
+	case strings.Contains(t.Base, "DATE"), strings.Contains(t.Base, "TIME"):
+		return gotype.Nullable(style, gotype.Type{Name: "time.Time", Import: "time"}, sqlGoType("NullTime"), gotype.Type{}), true
+	case strings.Contains(t.Base, "STRING"), strings.Contains(t.Base, "JSON"):
+		return gotype.Nullable(style, gotype.Type{Name: "string"}, sqlGoType("NullString"), gotype.Type{}), true
+	case strings.Contains(t.Base, "BYTES"), strings.Contains(t.Base, "BINARY"):
+		return gotype.Same(gotype.Type{Name: "[]byte"}), true
+	}
+	switch t.Affinity {
+	case AffinityInteger:
+		return gotype.Nullable(style, gotype.Type{Name: "int64"}, sqlGoType("NullInt64"), gotype.Type{}), true
+	case AffinityText:
+		return gotype.Nullable(style, gotype.Type{Name: "string"}, sqlGoType("NullString"), gotype.Type{}), true
+	case AffinityBlob:
+		return gotype.Same(gotype.Type{Name: "[]byte"}), true
+	default:
+		return gotype.Nullable(style, gotype.Type{Name: "float64"}, sqlGoType("NullFloat64"), gotype.Type{}), true
+	}
+}
+
+func sqlGoType(name string) gotype.Type {
+	return gotype.Type{Name: "sql." + name, Import: "database/sql"}
+}
@@ -0,0 +1,31 @@
+package sqlite3_test
+
+import (
+	"github.com/Jumpaku/schenerate/gotype"
+	"github.com/Jumpaku/schenerate/sqlite3"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDefaultGoType(t *testing.T) {
+	testcases := []struct {
+		in    string
+		style gotype.NullStyle
+		want  gotype.Mapping
+	}{
+		{in: "INTEGER", style: gotype.NullStylePointer, want: gotype.Mapping{Type: gotype.Type{Name: "int64"}}},
+		{in: "STRING(50)", style: gotype.NullStyleDatabaseSQL, want: gotype.Mapping{Type: gotype.Type{Name: "string"}, Null: gotype.Type{Name: "sql.NullString", Import: "database/sql"}}},
+		{in: "TIMESTAMP", style: gotype.NullStyleDriver, want: gotype.Mapping{Type: gotype.Type{Name: "time.Time", Import: "time"}, Null: gotype.Type{Name: "sql.NullTime", Import: "database/sql"}}},
+		{in: "BOOL", style: gotype.NullStyleDatabaseSQL, want: gotype.Mapping{Type: gotype.Type{Name: "bool"}, Null: gotype.Type{Name: "sql.NullBool", Import: "database/sql"}}},
+		{in: "BYTES(50)", style: gotype.NullStylePointer, want: gotype.Same(gotype.Type{Name: "[]byte"})},
+		{in: "NUMERIC", style: gotype.NullStylePointer, want: gotype.Mapping{Type: gotype.Type{Name: "float64"}}},
+		{in: "", style: gotype.NullStylePointer, want: gotype.Same(gotype.Type{Name: "[]byte"})},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.in, func(t *testing.T) {
+			got, ok := sqlite3.DefaultGoType(testcase.in, testcase.style)
+			require.True(t, ok)
+			require.Equal(t, testcase.want, got)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package sqlite3_test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Jumpaku/schenerate/introspect"
+	"github.com/Jumpaku/schenerate/introspect/introspecttest"
+	"github.com/Jumpaku/schenerate/sqlite3"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIntrospector(t *testing.T) {
+	introspecttest.Run(t, introspecttest.Config{
+		Setup: func(t *testing.T, ddl string) introspect.Introspector {
+			dbPath := filepath.Join(t.TempDir(), "test.sqlite")
+			db, err := sql.Open("sqlite3", dbPath)
+			if err != nil {
+				t.Fatalf("failed to open: %v", err)
+			}
+			defer db.Close()
+			if _, err := db.Exec(ddl); err != nil {
+				t.Fatalf("failed to exec: %v", err)
+			}
+
+			i, err := introspect.Open(context.Background(), sqlite3.DriverName, dbPath)
+			if err != nil {
+				t.Fatalf("failed to open: %v", err)
+			}
+			t.Cleanup(func() { i.Close() })
+			return i
+		},
+		DDL: os.DirFS("testdata/list"),
+	})
+}
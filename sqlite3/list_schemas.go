@@ -4,11 +4,46 @@ import (
 	"cmp"
 	"context"
 	"fmt"
+	"github.com/Jumpaku/schenerate/schema"
 	"github.com/samber/lo"
 	"slices"
 )
 
-func ListSchemas(ctx context.Context, q queryer, tables []string) (schemas Schemas, err error) {
+// ListSchemas lists the schemas of the tables, which are expanded by the references and the referrers according to opts.
+func ListSchemas(ctx context.Context, q Queryer, tables []string, opts ...schema.ListOption) (Schemas, error) {
+	return schema.Expand(ctx, tables, opts,
+		func(ctx context.Context, tables []string) ([]Schema, error) { return listSchemas(ctx, q, tables) },
+		func(s Schema) schema.Schema { return Schemas{s}.Unified()[0] },
+		func(ctx context.Context) (map[string][]string, error) { return listReferences(ctx, q) },
+	)
+}
+
+// listReferences returns the tables referenced by each table through foreign keys by a single query.
+func listReferences(ctx context.Context, q Queryer) (map[string][]string, error) {
+	type reference struct {
+		Name            string `db:"Name"`
+		ReferencedTable string `db:"ReferencedTable"`
+	}
+	rows, err := query[reference](ctx, q,
+		//language=SQL
+		`--sql query references of all the tables
+SELECT DISTINCT
+	t."name" AS Name,
+	fk."table" AS ReferencedTable
+FROM pragma_table_list() AS t
+	JOIN pragma_foreign_key_list(t."name", t."schema") AS fk
+ORDER BY t."name", fk."table"`)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to get references: %w`, err)
+	}
+	refs := map[string][]string{}
+	for _, r := range rows {
+		refs[r.Name] = append(refs[r.Name], r.ReferencedTable)
+	}
+	return refs, nil
+}
+
+func listSchemas(ctx context.Context, q Queryer, tables []string) (schemas Schemas, err error) {
 	for _, t := range tables {
 		schema, err := queryTable(ctx, q, t)
 		if err != nil {
@@ -37,7 +72,7 @@ func ListSchemas(ctx context.Context, q queryer, tables []string) (schemas Schem
 	return schemas, nil
 }
 
-func queryTable(ctx context.Context, q queryer, table string) (Schema, error) {
+func queryTable(ctx context.Context, q Queryer, table string) (Schema, error) {
 	type recordTable struct {
 		Schema string `db:"Schema"`
 		Name   string `db:"Name"`
@@ -63,7 +98,7 @@ FROM pragma_table_list(?)`, table)
 	return Schema{Name: record.Name, Type: record.Type}, nil
 }
 
-func queryColumns(ctx context.Context, q queryer, table string) ([]Column, error) {
+func queryColumns(ctx context.Context, q Queryer, table string) ([]Column, error) {
 	type column struct {
 		Name     string `db:"Name"`
 		Type     string `db:"Type"`
@@ -87,7 +122,7 @@ ORDER BY "cid"`, table)
 	}), nil
 }
 
-func queryPrimaryKey(ctx context.Context, q queryer, table string) ([]string, error) {
+func queryPrimaryKey(ctx context.Context, q Queryer, table string) ([]string, error) {
 	type name struct {
 		Name string `db:"Name"`
 	}
@@ -104,7 +139,7 @@ ORDER BY "pk"`, table)
 	return lo.Map(rows, func(item name, _ int) string { return item.Name }), nil
 }
 
-func queryForeignKeys(ctx context.Context, q queryer, table string) ([]ForeignKey, error) {
+func queryForeignKeys(ctx context.Context, q Queryer, table string) ([]ForeignKey, error) {
 	type fkRow struct {
 		Id           int64  `db:"Id"`
 		Seq          int64  `db:"Seq"`
@@ -146,7 +181,7 @@ ORDER BY "id", "seq"`, table)
 	return foreignKeys, nil
 }
 
-func queryIndexes(ctx context.Context, q queryer, table string) ([]Index, error) {
+func queryIndexes(ctx context.Context, q Queryer, table string) ([]Index, error) {
 	type idxRow struct {
 		Seq      int64  `db:"Seq"`
 		Name     string `db:"Name"`
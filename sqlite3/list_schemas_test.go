@@ -0,0 +1,42 @@
+package sqlite3_test
+
+import (
+	"context"
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/Jumpaku/schenerate/sqlite3"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestListSchemas_Expand(t *testing.T) {
+	q, err := sqlite3.OpenDDL(`
+CREATE TABLE customers (id INTEGER PRIMARY KEY);
+CREATE TABLE orders (id INTEGER PRIMARY KEY, customer_id INTEGER REFERENCES customers (id));
+CREATE TABLE order_items (id INTEGER PRIMARY KEY, order_id INTEGER REFERENCES orders (id), product_id INTEGER REFERENCES products (id));
+CREATE TABLE products (id INTEGER PRIMARY KEY, category_id INTEGER REFERENCES categories (id));
+CREATE TABLE categories (id INTEGER PRIMARY KEY);
+CREATE TABLE logs (id INTEGER PRIMARY KEY);`)
+	require.Nil(t, err)
+	defer q.Close()
+
+	testcases := []struct {
+		name   string
+		tables []string
+		opts   []schema.ListOption
+		want   []string
+	}{
+		{name: "no options", tables: []string{"order_items"}, want: []string{"order_items"}},
+		{name: "references", tables: []string{"order_items"}, opts: []schema.ListOption{schema.WithReferences(1)}, want: []string{"order_items", "products", "orders"}},
+		{name: "references unlimited", tables: []string{"order_items"}, opts: []schema.ListOption{schema.WithReferences(-1)}, want: []string{"order_items", "products", "orders", "categories", "customers"}},
+		{name: "referrers", tables: []string{"orders"}, opts: []schema.ListOption{schema.WithReferrers(1)}, want: []string{"orders", "order_items"}},
+		{name: "referrers and references", tables: []string{"customers"}, opts: []schema.ListOption{schema.WithReferrers(-1), schema.WithReferences(-1)}, want: []string{"customers", "orders", "order_items", "products", "categories"}},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			got, err := sqlite3.ListSchemas(context.Background(), q, testcase.tables, testcase.opts...)
+			require.Nil(t, err)
+			require.Equal(t, testcase.want, lo.Map(got, func(s sqlite3.Schema, _ int) string { return s.Name }))
+		})
+	}
+}
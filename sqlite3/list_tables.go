@@ -3,15 +3,17 @@ package sqlite3
 import (
 	"context"
 	"fmt"
+	"github.com/Jumpaku/schenerate/schema"
 	"github.com/samber/lo"
 )
 
 type Table struct {
 	Schema string
 	Name   string
+	Type   string
 }
 
-func ListTables(ctx context.Context, q queryer) ([]Table, error) {
+func ListTables(ctx context.Context, q Queryer) ([]Table, error) {
 	type table struct {
 		Schema string `db:"Schema"`
 		Name   string `db:"Name"`
@@ -27,6 +29,19 @@ ORDER BY "schema", "name"`)
 		return nil, fmt.Errorf("failed to list tables: %w", err)
 	}
 	return lo.Map(records, func(r table, _ int) Table {
-		return Table{Schema: r.Schema, Name: r.Name}
+		return Table{Schema: r.Schema, Name: r.Name, Type: r.Type}
 	}), nil
 }
+
+// SelectTables returns the names of the tables selected by the selector, which can be passed to ListSchemas.
+func SelectTables(ctx context.Context, q Queryer, selector schema.Selector) ([]string, error) {
+	tables, err := q.ListTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names, err := selector.SelectNames(tables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select tables: %w", err)
+	}
+	return names, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
+	"github.com/Jumpaku/schenerate/schema"
 	"github.com/Jumpaku/schenerate/sqlite3"
 	"github.com/stretchr/testify/require"
 	"testing"
@@ -22,10 +23,12 @@ func TestListTables(t *testing.T) {
 				{
 					Schema: "main",
 					Name:   "sqlite_schema",
+					Type:   "table",
 				},
 				{
 					Schema: "temp",
 					Name:   "sqlite_temp_schema",
+					Type:   "table",
 				},
 			},
 		},
@@ -42,23 +45,23 @@ func TestListTables(t *testing.T) {
 				list_ddl08UniqueKeysColumn,
 			},
 			want: []sqlite3.Table{
-				{Schema: "main", Name: "A"},
-				{Schema: "main", Name: "C_1"},
-				{Schema: "main", Name: "C_2"},
-				{Schema: "main", Name: "C_3"},
-				{Schema: "main", Name: "C_4"},
-				{Schema: "main", Name: "C_5"},
-				{Schema: "main", Name: "D_1"},
-				{Schema: "main", Name: "E_1"},
-				{Schema: "main", Name: "E_2"},
-				{Schema: "main", Name: "F_1"},
-				{Schema: "main", Name: "F_2"},
-				{Schema: "main", Name: "F_3"},
-				{Schema: "main", Name: "G"},
-				{Schema: "main", Name: "H"},
-				{Schema: "main", Name: "I"},
-				{Schema: "main", Name: "sqlite_schema"},
-				{Schema: "temp", Name: "sqlite_temp_schema"},
+				{Schema: "main", Name: "A", Type: "table"},
+				{Schema: "main", Name: "C_1", Type: "table"},
+				{Schema: "main", Name: "C_2", Type: "table"},
+				{Schema: "main", Name: "C_3", Type: "table"},
+				{Schema: "main", Name: "C_4", Type: "table"},
+				{Schema: "main", Name: "C_5", Type: "table"},
+				{Schema: "main", Name: "D_1", Type: "table"},
+				{Schema: "main", Name: "E_1", Type: "table"},
+				{Schema: "main", Name: "E_2", Type: "table"},
+				{Schema: "main", Name: "F_1", Type: "table"},
+				{Schema: "main", Name: "F_2", Type: "table"},
+				{Schema: "main", Name: "F_3", Type: "table"},
+				{Schema: "main", Name: "G", Type: "table"},
+				{Schema: "main", Name: "H", Type: "table"},
+				{Schema: "main", Name: "I", Type: "table"},
+				{Schema: "main", Name: "sqlite_schema", Type: "table"},
+				{Schema: "temp", Name: "sqlite_temp_schema", Type: "table"},
 			},
 		},
 	}
@@ -99,3 +102,36 @@ var list_ddl07UniqueKeysConstraint string
 
 //go:embed testdata/list/ddl_08_unique_keys_column.sql
 var list_ddl08UniqueKeysColumn string
+
+func TestSelectTables(t *testing.T) {
+	q, err := sqlite3.OpenDDL(`
+CREATE TABLE users (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT);
+CREATE TABLE user_items (id INTEGER PRIMARY KEY, user_id INTEGER REFERENCES users (id));
+CREATE VIEW user_names AS SELECT name FROM users;`)
+	require.Nil(t, err)
+	defer q.Close()
+
+	testcases := []struct {
+		name     string
+		selector schema.Selector
+		want     []string
+		wantErr  bool
+	}{
+		{name: "all", selector: schema.All(), want: []string{"user_items", "user_names", "users"}},
+		{name: "system", selector: schema.Selector{System: true, Schemas: []string{"main"}}, want: []string{"sqlite_schema", "sqlite_sequence", "user_items", "user_names", "users"}},
+		{name: "tables", selector: schema.Selector{Types: []string{schema.TableTypeTable}}, want: []string{"user_items", "users"}},
+		{name: "patterns", selector: schema.Selector{Include: []string{"user*"}, Exclude: []string{"/_names$/"}}, want: []string{"user_items", "users"}},
+		{name: "invalid pattern", selector: schema.Selector{Include: []string{"/(/"}}, wantErr: true},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			got, err := sqlite3.SelectTables(context.Background(), q, testcase.selector)
+			if testcase.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, testcase.want, got)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package sqlite3
+
+import (
+	"context"
+	"fmt"
+	"github.com/Jumpaku/schenerate/migrations"
+	"github.com/Jumpaku/schenerate/schema"
+)
+
+// ListSchemasFromMigrations applies the up-migrations in the directory up to and including the version to a private in-memory database
+// and returns the schemas of all the tables, where all the migrations are applied if the version is empty.
+func ListSchemasFromMigrations(ctx context.Context, dir string, version string) (Schemas, error) {
+	ms, err := migrations.Load(dir)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to load migrations: %w`, err)
+	}
+	ms, err = ms.UpTo(version)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to select migrations: %w`, err)
+	}
+
+	q, err := OpenDDL(ms.Up()...)
+	if err != nil {
+		return nil, fmt.Errorf(`fail to apply migrations: %w`, err)
+	}
+	defer q.Close()
+
+	tables, err := SelectTables(ctx, q, schema.Selector{Schemas: []string{"main"}})
+	if err != nil {
+		return nil, err
+	}
+	return ListSchemas(ctx, q, tables)
+}
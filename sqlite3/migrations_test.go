@@ -0,0 +1,48 @@
+package sqlite3_test
+
+import (
+	"context"
+	"github.com/Jumpaku/schenerate/sqlite3"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestListSchemasFromMigrations(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"1_create_a.up.sql":   `CREATE TABLE A (Id INTEGER PRIMARY KEY AUTOINCREMENT, Name TEXT NOT NULL);`,
+		"1_create_a.down.sql": `DROP TABLE A;`,
+		"2_create_b.up.sql":   `CREATE TABLE B (Id INTEGER PRIMARY KEY, AId INTEGER REFERENCES A (Id)); CREATE INDEX IDX_B_AId ON B (AId);`,
+		"3_alter_a.up.sql":    `ALTER TABLE A ADD COLUMN Note TEXT;`,
+	} {
+		require.Nil(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	testcases := []struct {
+		name        string
+		version     string
+		wantTables  []string
+		wantColumns []string
+		wantErr     bool
+	}{
+		{name: "latest", version: "", wantTables: []string{"A", "B"}, wantColumns: []string{"Id", "Name", "Note"}},
+		{name: "version 2", version: "2", wantTables: []string{"A", "B"}, wantColumns: []string{"Id", "Name"}},
+		{name: "version 1", version: "1", wantTables: []string{"A"}, wantColumns: []string{"Id", "Name"}},
+		{name: "unknown version", version: "4", wantErr: true},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			got, err := sqlite3.ListSchemasFromMigrations(context.Background(), dir, testcase.version)
+			if testcase.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, testcase.wantTables, lo.Map(got, func(s sqlite3.Schema, _ int) string { return s.Name }))
+			require.Equal(t, testcase.wantColumns, lo.Map(got[0].Columns, func(c sqlite3.Column, _ int) string { return c.Name }))
+		})
+	}
+}
@@ -6,7 +6,7 @@ import (
 	"reflect"
 )
 
-func QueryRows[RecordStruct any](ctx context.Context, q queryer, stmt string, params []any) (records []RecordStruct, err error) {
+func QueryRows[RecordStruct any](ctx context.Context, q Queryer, stmt string, params []any) (records []RecordStruct, err error) {
 	{
 		var rs RecordStruct
 		rv := reflect.ValueOf(rs)
@@ -4,27 +4,97 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"github.com/Jumpaku/schenerate/internal/slice"
+	"github.com/Jumpaku/schenerate/schema"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/mattn/go-sqlite3"
 )
 
-type queryer struct {
+// Queryer queries a SQLite3 database, which implements schema.Queryer.
+type Queryer struct {
 	dbx *sqlx.DB
 }
 
-func Open(dsn string) (queryer, error) {
+func Open(dsn string) (Queryer, error) {
 	db, err := sql.Open("sqlite3", dsn)
 	if err != nil {
-		return queryer{}, fmt.Errorf("failed to open: %w", err)
+		return Queryer{}, fmt.Errorf("failed to open: %w", err)
 	}
-	return queryer{dbx: sqlx.NewDb(db, "sqlite3")}, nil
+	return Queryer{dbx: sqlx.NewDb(db, "sqlite3")}, nil
 }
 
-func (q queryer) Close() error {
+// OpenDDL opens a private in-memory database to which the DDL statements are applied,
+// so that the schemas can be listed from DDL files without a database file.
+func OpenDDL(ddls ...string) (Queryer, error) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		return Queryer{}, fmt.Errorf("failed to open: %w", err)
+	}
+	// Each connection to :memory: has its own database, so only one connection is kept.
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	db.SetConnMaxLifetime(0)
+	db.SetConnMaxIdleTime(0)
+	for i, ddl := range ddls {
+		if _, err := db.Exec(ddl); err != nil {
+			db.Close()
+			return Queryer{}, fmt.Errorf("failed to exec DDL %d: %w", i, err)
+		}
+	}
+	return Queryer{dbx: sqlx.NewDb(db, "sqlite3")}, nil
+}
+
+func (q Queryer) Close() error {
 	return q.dbx.Close()
 }
 
-func query[Record any](ctx context.Context, q queryer, stmt string, args ...any) (records []Record, err error) {
+var _ schema.Queryer = Queryer{}
+
+func (q Queryer) ListTables(ctx context.Context) ([]schema.Table, error) {
+	tables, err := ListTables(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(tables, func(t Table) schema.Table {
+		return schema.Table{Schema: t.Schema, Name: t.Name, Type: t.Type}
+	}), nil
+}
+
+// ListReferences returns the tables referenced by each table by a single query, which is used by introspect.ListSchemas.
+func (q Queryer) ListReferences(ctx context.Context) (map[string][]string, error) {
+	return listReferences(ctx, q)
+}
+
+func (q Queryer) ListSchemas(ctx context.Context, tables []string) (schema.Schemas, error) {
+	schemas, err := ListSchemas(ctx, q, tables)
+	if err != nil {
+		return nil, err
+	}
+	return schemas.Unified(), nil
+}
+
+func (q Queryer) QueryRows(ctx context.Context, stmt string, params []any) (records []schema.Row, err error) {
+	rows, err := q.dbx.QueryxContext(ctx, stmt, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		record := schema.Row{}
+		if err := rows.MapScan(record); err != nil {
+			return nil, fmt.Errorf("failed to scan: %w", err)
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+
+	return records, nil
+}
+
+func query[Record any](ctx context.Context, q Queryer, stmt string, args ...any) (records []Record, err error) {
 	rows, err := q.dbx.QueryxContext(ctx, stmt, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query: %w", err)
@@ -0,0 +1,99 @@
+package sqlite3_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/Jumpaku/schenerate/files"
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/Jumpaku/schenerate/sqlite3"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestQueryer_QueryRows(t *testing.T) {
+	testcases := []struct {
+		name     string
+		inStmt   string
+		inParams []any
+		want     []schema.Row
+	}{
+		{
+			name:   "none",
+			inStmt: `SELECT * FROM (SELECT 'A' AS "name", 1 AS "age") WHERE FALSE`,
+			want:   nil,
+		},
+		{
+			name:   "one",
+			inStmt: `SELECT 'A' AS "name", 1 AS "age", NULL AS "note"`,
+			want:   []schema.Row{{"name": "A", "age": int64(1), "note": nil}},
+		},
+		{
+			name:     "params",
+			inStmt:   `SELECT ? AS "name", ? AS "age"`,
+			inParams: []any{"B", 2},
+			want:     []schema.Row{{"name": "B", "age": int64(2)}},
+		},
+	}
+	for number, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			dbPath := fmt.Sprintf(`test_%d_%d.sqlite`, number, time.Now().Unix())
+			q, teardown := sqlite3.Setup(t, dbPath, nil)
+			defer teardown()
+
+			got, err := q.QueryRows(context.Background(), testcase.inStmt, testcase.inParams)
+
+			require.Nil(t, err)
+			require.Equal(t, testcase.want, got)
+		})
+	}
+}
+
+func TestQueryer_Schema(t *testing.T) {
+	dbPath := fmt.Sprintf(`test_queryer_%d.sqlite`, time.Now().Unix())
+	q, teardown := sqlite3.Setup(t, dbPath, []string{
+		`CREATE TABLE A (id INTEGER PRIMARY KEY, name TEXT NOT NULL UNIQUE)`,
+		`CREATE TABLE B (id INTEGER PRIMARY KEY, a_id INTEGER REFERENCES A (id))`,
+	})
+	defer teardown()
+
+	var sq schema.Queryer = q
+
+	tables, err := sq.ListTables(context.Background())
+	require.Nil(t, err)
+	require.Contains(t, tables, schema.Table{Schema: "main", Name: "A", Type: "table"})
+	require.Contains(t, tables, schema.Table{Schema: "main", Name: "B", Type: "table"})
+
+	var got schema.Schemas
+	err = schema.GenerateWithSchema(context.Background(), sq, []string{"A", "B"}, func(w *files.Writer, schemas schema.Schemas) error {
+		got = schemas
+		return nil
+	})
+	require.Nil(t, err)
+	require.Len(t, got, 2)
+	a, ok := got.Find("", "A")
+	require.True(t, ok)
+	require.Equal(t, schema.DialectSQLite3, a.Dialect)
+	require.Equal(t, []schema.UniqueKey{{Name: a.UniqueKeys[0].Name, Key: []string{"name"}}}, a.UniqueKeys)
+	b, ok := got.Find("", "B")
+	require.True(t, ok)
+	require.Equal(t, "A", b.ForeignKeys[0].Reference.Table)
+}
+
+func TestOpenDDL(t *testing.T) {
+	q, err := sqlite3.OpenDDL(`CREATE TABLE A (Id INTEGER PRIMARY KEY, Name TEXT NOT NULL)`, `CREATE INDEX IDX_A_Name ON A (Name)`)
+	require.Nil(t, err)
+	defer q.Close()
+
+	got, err := sqlite3.ListSchemas(context.Background(), q, []string{"A"})
+	require.Nil(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, []sqlite3.Column{
+		{Name: "Id", Type: "INTEGER", Nullable: true},
+		{Name: "Name", Type: "TEXT", Nullable: false},
+	}, got[0].Columns)
+	require.Equal(t, []string{"Id"}, got[0].PrimaryKey)
+
+	_, err = sqlite3.OpenDDL(`CREATE TABLE`)
+	require.NotNil(t, err)
+}
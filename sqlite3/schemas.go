@@ -25,35 +25,35 @@ func (s Schemas) BuildGraph() graph.Graph[Schema] {
 }
 
 type Schema struct {
-	Name        string
-	Type        string
-	Columns     []Column
-	PrimaryKey  []string
-	ForeignKeys []ForeignKey
-	Indexes     []Index
+	Name        string       `json:"name" yaml:"name"`
+	Type        string       `json:"type" yaml:"type"`
+	Columns     []Column     `json:"columns" yaml:"columns"`
+	PrimaryKey  []string     `json:"primaryKey" yaml:"primaryKey"`
+	ForeignKeys []ForeignKey `json:"foreignKeys" yaml:"foreignKeys"`
+	Indexes     []Index      `json:"indexes" yaml:"indexes"`
 }
 
 type Column struct {
-	Name     string
-	Type     string
-	Nullable bool
+	Name     string `json:"name" yaml:"name"`
+	Type     string `json:"type" yaml:"type"`
+	Nullable bool   `json:"nullable" yaml:"nullable"`
 }
 
 type ForeignKey struct {
-	Key       []string
-	Reference ForeignKeyReference
+	Key       []string            `json:"key" yaml:"key"`
+	Reference ForeignKeyReference `json:"reference" yaml:"reference"`
 }
 
 type ForeignKeyReference struct {
-	Table string
-	Key   []string
+	Table string   `json:"table" yaml:"table"`
+	Key   []string `json:"key" yaml:"key"`
 }
 
 type Index struct {
-	Name   string
-	Origin IndexOrigin
-	Unique bool
-	Key    []IndexKeyElem
+	Name   string         `json:"name" yaml:"name"`
+	Origin IndexOrigin    `json:"origin" yaml:"origin"`
+	Unique bool           `json:"unique" yaml:"unique"`
+	Key    []IndexKeyElem `json:"key" yaml:"key"`
 }
 
 // IndexOrigin is a type for the origin of an index.
@@ -68,6 +68,6 @@ const (
 )
 
 type IndexKeyElem struct {
-	Name string
-	Desc bool
+	Name string `json:"name" yaml:"name"`
+	Desc bool   `json:"desc" yaml:"desc"`
 }
@@ -6,7 +6,7 @@ import (
 	"testing"
 )
 
-func Setup(t *testing.T, dbPath string, ddls []string) (q queryer, teardown func()) {
+func Setup(t *testing.T, dbPath string, ddls []string) (q Queryer, teardown func()) {
 	t.Helper()
 
 	db, err := sql.Open("sqlite3", dbPath)
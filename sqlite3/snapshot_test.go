@@ -0,0 +1,65 @@
+package sqlite3_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/Jumpaku/schenerate/files"
+	"github.com/Jumpaku/schenerate/snapshot"
+	"github.com/Jumpaku/schenerate/sqlite3"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSnapshot(t *testing.T) {
+	dbPath := fmt.Sprintf(`test_snapshot_%d.sqlite`, time.Now().Unix())
+	q, teardown := sqlite3.Setup(t, dbPath, []string{list_ddl02ForeignKeys, list_ddl06UniqueKeysIndex})
+	defer teardown()
+
+	schemas, err := sqlite3.ListSchemas(context.Background(), q, []string{"G", "C_5", "C_1", "C_3", "C_2", "C_4"})
+	require.Nil(t, err)
+
+	for _, format := range []snapshot.Format{snapshot.FormatJSON, snapshot.FormatYAML} {
+		t.Run(string(format), func(t *testing.T) {
+			b, err := schemas.MarshalSnapshot(format)
+			require.Nil(t, err)
+
+			reversed := append(sqlite3.Schemas{}, schemas...)
+			for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+				reversed[i], reversed[j] = reversed[j], reversed[i]
+			}
+			b2, err := reversed.MarshalSnapshot(format)
+			require.Nil(t, err)
+			require.Equal(t, string(b), string(b2))
+
+			got, err := sqlite3.UnmarshalSnapshot(format, b)
+			require.Nil(t, err)
+			require.Equal(t, []string{"C_1", "C_2", "C_3", "C_4", "C_5", "G"}, names(got))
+
+			b3, err := got.MarshalSnapshot(format)
+			require.Nil(t, err)
+			require.Equal(t, string(b), string(b3))
+		})
+	}
+	t.Run("generate", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "snapshot.yaml")
+		require.Nil(t, schemas.SaveSnapshot(path))
+
+		var got sqlite3.Schemas
+		err := sqlite3.GenerateWithSnapshot(path, func(w *files.Writer, schemas sqlite3.Schemas) error {
+			got = schemas
+			return nil
+		})
+		require.Nil(t, err)
+		require.Equal(t, []string{"C_1", "C_2", "C_3", "C_4", "C_5", "G"}, names(got))
+	})
+}
+
+func names(schemas sqlite3.Schemas) []string {
+	var names []string
+	for _, s := range schemas {
+		names = append(names, s.Name)
+	}
+	return names
+}
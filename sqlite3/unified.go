@@ -0,0 +1,122 @@
+package sqlite3
+
+import (
+	"cmp"
+	"fmt"
+	"github.com/Jumpaku/schenerate/internal/slice"
+	"github.com/Jumpaku/schenerate/schema"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Unified converts the schemas into the dialect-neutral schemas.
+// The unique keys of the dialect-neutral schemas are derived from the indexes created by UNIQUE constraints,
+// which are ordered as declared by the numbers of their names sqlite_autoindex_<table>_<N>.
+func (s Schemas) Unified() schema.Schemas {
+	return slice.Map(s, func(s Schema) schema.Schema {
+		var uniqueKeys []schema.UniqueKey
+		for _, idx := range s.Indexes {
+			if idx.Origin == IndexOriginUniqueConstraint {
+				uniqueKeys = append(uniqueKeys, schema.UniqueKey{
+					Name: idx.Name,
+					Key:  slice.Map(idx.Key, func(k IndexKeyElem) string { return k.Name }),
+				})
+			}
+		}
+		slices.SortStableFunc(uniqueKeys, func(a, b schema.UniqueKey) int {
+			return cmp.Compare(autoindexNumber(a.Name), autoindexNumber(b.Name))
+		})
+		return schema.Schema{
+			Dialect: schema.DialectSQLite3,
+			Name:    s.Name,
+			Type:    s.Type,
+			Columns: slice.Map(s.Columns, func(c Column) schema.Column {
+				return schema.Column{Name: c.Name, Type: c.Type, Nullable: c.Nullable}
+			}),
+			PrimaryKey: s.PrimaryKey,
+			ForeignKeys: slice.Map(s.ForeignKeys, func(fk ForeignKey) schema.ForeignKey {
+				return schema.ForeignKey{
+					Key: fk.Key,
+					Reference: schema.ForeignKeyReference{
+						Table: fk.Reference.Table,
+						Key:   fk.Reference.Key,
+					},
+				}
+			}),
+			UniqueKeys: uniqueKeys,
+			Indexes: slice.Map(s.Indexes, func(idx Index) schema.Index {
+				return schema.Index{
+					Name:   idx.Name,
+					Origin: string(idx.Origin),
+					Unique: idx.Unique,
+					Key: slice.Map(idx.Key, func(k IndexKeyElem) schema.IndexKeyElem {
+						return schema.IndexKeyElem{Name: k.Name, Desc: k.Desc}
+					}),
+				}
+			}),
+		}
+	})
+}
+
+// FromUnified converts the dialect-neutral schemas into the schemas of SQLite3.
+// The unique keys are ignored since they are derived from the indexes.
+func FromUnified(schemas schema.Schemas) (Schemas, error) {
+	if schemas == nil {
+		return nil, nil
+	}
+	result := Schemas{}
+	for _, s := range schemas {
+		if s.Dialect != schema.DialectSQLite3 {
+			return nil, fmt.Errorf(`fail to convert schema of %s: dialect %q is not %q`, s.Name, s.Dialect, schema.DialectSQLite3)
+		}
+		if s.Schema != "" {
+			return nil, fmt.Errorf(`fail to convert schema of %s: schema %q is not supported`, s.Name, s.Schema)
+		}
+		if s.Parent != "" {
+			return nil, fmt.Errorf(`fail to convert schema of %s: parent is not supported`, s.Name)
+		}
+		for _, fk := range s.ForeignKeys {
+			if fk.Name != "" || fk.Reference.Schema != "" {
+				return nil, fmt.Errorf(`fail to convert foreign key of %s: name and schema of reference are not supported`, s.Name)
+			}
+		}
+		result = append(result, Schema{
+			Name: s.Name,
+			Type: s.Type,
+			Columns: slice.Map(s.Columns, func(c schema.Column) Column {
+				return Column{Name: c.Name, Type: c.Type, Nullable: c.Nullable}
+			}),
+			PrimaryKey: s.PrimaryKey,
+			ForeignKeys: slice.Map(s.ForeignKeys, func(fk schema.ForeignKey) ForeignKey {
+				return ForeignKey{
+					Key: fk.Key,
+					Reference: ForeignKeyReference{
+						Table: fk.Reference.Table,
+						Key:   fk.Reference.Key,
+					},
+				}
+			}),
+			Indexes: slice.Map(s.Indexes, func(idx schema.Index) Index {
+				return Index{
+					Name:   idx.Name,
+					Origin: IndexOrigin(idx.Origin),
+					Unique: idx.Unique,
+					Key: slice.Map(idx.Key, func(k schema.IndexKeyElem) IndexKeyElem {
+						return IndexKeyElem{Name: k.Name, Desc: k.Desc}
+					}),
+				}
+			}),
+		})
+	}
+	return result, nil
+}
+
+// autoindexNumber returns N of the index name sqlite_autoindex_<table>_<N>, or 0 if the name is not in the form.
+func autoindexNumber(name string) int {
+	if !strings.HasPrefix(name, "sqlite_autoindex_") {
+		return 0
+	}
+	n, _ := strconv.Atoi(name[strings.LastIndex(name, "_")+1:])
+	return n
+}
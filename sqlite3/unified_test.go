@@ -0,0 +1,51 @@
+package sqlite3_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/Jumpaku/schenerate/sqlite3"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestSchemas_Unified(t *testing.T) {
+	dbPath := fmt.Sprintf(`test_unified_%d.sqlite`, time.Now().Unix())
+	q, teardown := sqlite3.Setup(t, dbPath, []string{
+		generate_ddl00AllTypes,
+		generate_ddl02ForeignKeys,
+		generate_ddl05ForeignLoop3,
+		generate_ddl06UniqueKeysIndex,
+		generate_ddl08UniqueKeysColumn,
+	})
+	defer teardown()
+
+	schemas, err := sqlite3.ListSchemas(context.Background(), q, []string{"A", "C_1", "C_2", "C_3", "C_4", "C_5", "F_1", "F_2", "F_3", "G", "I"})
+	require.Nil(t, err)
+
+	got := schemas.Unified()
+
+	i, ok := got.Find("", "I")
+	require.True(t, ok)
+	require.Equal(t, schema.DialectSQLite3, i.Dialect)
+	require.Equal(t, []schema.UniqueKey{
+		{Name: "sqlite_autoindex_I_1", Key: []string{"C1"}},
+		{Name: "sqlite_autoindex_I_2", Key: []string{"C2"}},
+		{Name: "sqlite_autoindex_I_3", Key: []string{"C3"}},
+	}, i.UniqueKeys)
+	g, ok := got.Find("", "G")
+	require.True(t, ok)
+	require.Empty(t, g.UniqueKeys)
+
+	back, err := sqlite3.FromUnified(got)
+	require.Nil(t, err)
+	require.Equal(t, schemas, back)
+}
+
+func TestFromUnified_Error(t *testing.T) {
+	_, err := sqlite3.FromUnified(schema.Schemas{{Dialect: schema.DialectPostgres, Name: "A"}})
+	require.Error(t, err)
+	_, err = sqlite3.FromUnified(schema.Schemas{{Dialect: schema.DialectSQLite3, Name: "A", Parent: "B"}})
+	require.Error(t, err)
+}
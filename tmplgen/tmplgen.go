@@ -0,0 +1,185 @@
+package tmplgen
+
+import (
+	"fmt"
+	"github.com/Jumpaku/schenerate/ddl"
+	"github.com/Jumpaku/schenerate/files"
+	"github.com/Jumpaku/schenerate/gotype"
+	"github.com/Jumpaku/schenerate/name"
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/samber/lo"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// Data is passed to the templates.
+type Data struct {
+	Schemas schema.Schemas
+	// Order is the schemas in the order of the graph of references, where referenced and parent tables precede.
+	Order schema.Schemas
+	// Cyclic is true if foreign keys are cyclic, in which case Order only considers interleaving.
+	Cyclic bool
+	// Schema is the schema for which a template is executed per schema, which is zero for other templates.
+	Schema schema.Schema
+}
+
+const (
+	templateSuffix = ".tmpl"
+	eachSuffix     = ".each.tmpl"
+	partialPrefix  = "_"
+)
+
+// Generator executes the templates in a directory to generate files.
+// The files in the directory are handled by their names as follows, where the other files are ignored:
+//
+//   - _*.tmpl: partials shared by the other templates, which are referred to by their paths, e.g. {{template "_header.tmpl" .}}.
+//   - *.each.tmpl: executed per schema and written to the path of the file name without .each.tmpl.
+//   - *.tmpl: executed once for all the schemas and written to the path of the file name without .tmpl.
+//
+// The output paths are relative to the output directory and executed as templates with the same data,
+// e.g. "{{.Schema.Name | snake}}.go.each.tmpl". The functions of name.FuncMap and WithFuncs are available in the templates and the paths.
+type Generator struct {
+	outDir    string
+	funcs     template.FuncMap
+	templates []*template.Template
+}
+
+// Option configures a Generator.
+type Option func(*options)
+
+type options struct {
+	funcs template.FuncMap
+}
+
+// WithFuncs adds the functions to the templates and the paths, which override the functions of name.FuncMap of the same names.
+func WithFuncs(funcs template.FuncMap) Option {
+	return func(o *options) {
+		for k, v := range funcs {
+			o.funcs[k] = v
+		}
+	}
+}
+
+// GoTypeFuncs returns the functions mapping columns to Go types by the mapper.
+//
+//   - goType: returns the gotype.Type of a column of a table, e.g. {{goType .Schema $column}}.
+//   - goImports: returns the import paths required by the Go types of the columns of a table, e.g. {{goImports .Schema}}.
+func GoTypeFuncs(m *gotype.Mapper) template.FuncMap {
+	goType := func(t schema.Schema, c schema.Column) (gotype.Type, error) {
+		return m.Map(t.Name, c.Name, c.Type, c.Nullable)
+	}
+	return template.FuncMap{
+		"goType": goType,
+		"goImports": func(t schema.Schema) ([]string, error) {
+			var types []gotype.Type
+			for _, c := range t.Columns {
+				gt, err := goType(t, c)
+				if err != nil {
+					return nil, err
+				}
+				types = append(types, gt)
+			}
+			return gotype.Imports(types...), nil
+		},
+	}
+}
+
+// New parses the templates in fsys, whose outputs are written under outDir.
+func New(fsys fs.FS, outDir string, opts ...Option) (*Generator, error) {
+	o := options{funcs: name.FuncMap()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var partials, templates []string
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir() || !strings.HasSuffix(p, templateSuffix):
+		case strings.HasPrefix(path.Base(p), partialPrefix):
+			partials = append(partials, p)
+		default:
+			templates = append(templates, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(`fail to walk templates: %w`, err)
+	}
+
+	base := template.New("").Funcs(o.funcs).Option("missingkey=error")
+	for _, p := range partials {
+		if err := parse(fsys, base, p); err != nil {
+			return nil, err
+		}
+	}
+	g := &Generator{outDir: outDir, funcs: o.funcs}
+	for _, p := range templates {
+		t, err := base.Clone()
+		if err != nil {
+			return nil, fmt.Errorf(`fail to clone partials: %w`, err)
+		}
+		if err := parse(fsys, t, p); err != nil {
+			return nil, err
+		}
+		g.templates = append(g.templates, t.Lookup(p))
+	}
+	return g, nil
+}
+
+func parse(fsys fs.FS, t *template.Template, p string) error {
+	b, err := fs.ReadFile(fsys, p)
+	if err != nil {
+		return fmt.Errorf(`fail to read template %s: %w`, p, err)
+	}
+	if _, err := t.New(p).Parse(string(b)); err != nil {
+		return fmt.Errorf(`fail to parse template %s: %w`, p, err)
+	}
+	return nil
+}
+
+// Generate executes the templates with the schemas, which can be passed to schema.GenerateWithSchema.
+func (g *Generator) Generate(out *files.Writer, schemas schema.Schemas) error {
+	order, cyclic := ddl.CreationOrder(schemas)
+	data := Data{
+		Schemas: schemas,
+		Order:   lo.Map(order, func(i int, _ int) schema.Schema { return schemas[i] }),
+		Cyclic:  cyclic,
+	}
+	for _, t := range g.templates {
+		if !strings.HasSuffix(t.Name(), eachSuffix) {
+			if err := g.execute(out, t, strings.TrimSuffix(t.Name(), templateSuffix), data); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, s := range schemas {
+			data := data
+			data.Schema = s
+			if err := g.execute(out, t, strings.TrimSuffix(t.Name(), eachSuffix), data); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (g *Generator) execute(out *files.Writer, t *template.Template, pathPattern string, data Data) error {
+	p, err := name.FormatPath(pathPattern, data, g.funcs)
+	if err != nil {
+		return fmt.Errorf(`fail to format output path of %s: %w`, t.Name(), err)
+	}
+	if !filepath.IsLocal(filepath.FromSlash(p)) {
+		return fmt.Errorf(`output path %q of %s is outside of the output directory`, p, t.Name())
+	}
+	out.Add(filepath.Join(g.outDir, filepath.FromSlash(p)))
+	if err := t.Execute(out, data); err != nil {
+		return fmt.Errorf(`fail to execute template %s for %s: %w`, t.Name(), p, err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package tmplgen_test
+
+import (
+	"github.com/Jumpaku/schenerate/files"
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/Jumpaku/schenerate/tmplgen"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestGenerator_Generate(t *testing.T) {
+	schemas := schema.Schemas{
+		{Dialect: schema.DialectSQLite3, Name: "user_items", Columns: []schema.Column{{Name: "id", Type: "INTEGER"}, {Name: "user_id", Type: "INTEGER"}},
+			ForeignKeys: []schema.ForeignKey{{Key: []string{"user_id"}, Reference: schema.ForeignKeyReference{Table: "users", Key: []string{"id"}}}}},
+		{Dialect: schema.DialectSQLite3, Name: "users", Columns: []schema.Column{{Name: "id", Type: "INTEGER"}}},
+	}
+	testcases := []struct {
+		name      string
+		templates fstest.MapFS
+		want      map[string]string
+		wantErr   bool
+	}{
+		{
+			name: "once and each with partials",
+			templates: fstest.MapFS{
+				"_header.tmpl":    {Data: []byte(`{{define "header"}}// Code generated. DO NOT EDIT.{{end}}`)},
+				"_columns.tmpl":   {Data: []byte(`{{range .Schema.Columns}}{{.Name | pascal}} {{end}}`)},
+				"tables.txt.tmpl": {Data: []byte(`{{template "header"}}{{range .Order}} {{.Name}}{{end}} cyclic={{.Cyclic}}`)},
+				"models/{{.Schema.Name | snake}}.go.each.tmpl": {Data: []byte(`type {{.Schema.Name | singular | pascal}} struct { {{template "_columns.tmpl" .}}}`)},
+				"README.md": {Data: []byte(`ignored`)},
+			},
+			want: map[string]string{
+				"tables.txt":           `// Code generated. DO NOT EDIT. users user_items cyclic=false`,
+				"models/user_items.go": `type UserItem struct { Id UserId }`,
+				"models/users.go":      `type User struct { Id }`,
+			},
+		},
+		{
+			name:      "parse error",
+			templates: fstest.MapFS{"a.tmpl": {Data: []byte(`{{.Schemas`)}},
+			wantErr:   true,
+		},
+		{
+			name:      "path outside of output directory",
+			templates: fstest.MapFS{`{{printf "../%s" "x"}}.txt.tmpl`: {Data: []byte(`x`)}},
+			wantErr:   true,
+		},
+		{
+			name:      "execution error",
+			templates: fstest.MapFS{"a.tmpl": {Data: []byte(`{{.Unknown}}`)}},
+			wantErr:   true,
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			outDir := t.TempDir()
+			g, err := tmplgen.New(testcase.templates, outDir)
+			if err == nil {
+				w := &files.Writer{}
+				err = g.Generate(w, schemas)
+				if err == nil {
+					err = w.SaveAll()
+				}
+			}
+			if testcase.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+
+			got := map[string]string{}
+			err = filepath.WalkDir(outDir, func(p string, d os.DirEntry, err error) error {
+				if err != nil || d.IsDir() {
+					return err
+				}
+				b, err := os.ReadFile(p)
+				if err != nil {
+					return err
+				}
+				rel, _ := filepath.Rel(outDir, p)
+				got[filepath.ToSlash(rel)] = string(b)
+				return nil
+			})
+			require.Nil(t, err)
+			require.Equal(t, testcase.want, got)
+		})
+	}
+}
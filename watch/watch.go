@@ -0,0 +1,187 @@
+// Package watch re-runs generation when its inputs, such as DDL files, migrations, templates and database schemas, change.
+package watch
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Jumpaku/schenerate/schema"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Source returns a fingerprint of inputs, which changes when the inputs change.
+type Source func(ctx context.Context) (string, error)
+
+// Files returns a Source of the contents of the files, where directories are walked recursively and missing paths are allowed.
+func Files(paths ...string) Source {
+	return func(ctx context.Context) (string, error) {
+		h := sha256.New()
+		for _, p := range paths {
+			err := filepath.WalkDir(p, func(path string, d fs.DirEntry, err error) error {
+				if errors.Is(err, fs.ErrNotExist) {
+					fmt.Fprintf(h, "missing %s\n", path)
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				if d.IsDir() {
+					return nil
+				}
+				f, err := os.Open(path)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				fmt.Fprintf(h, "file %s\n", path)
+				_, err = io.Copy(h, f)
+				return err
+			})
+			if err != nil {
+				return "", fmt.Errorf(`fail to read %s: %w`, p, err)
+			}
+		}
+		return hex.EncodeToString(h.Sum(nil)), nil
+	}
+}
+
+// Schemas returns a Source of the schemas listed by list, e.g. from a live database.
+func Schemas(list func(ctx context.Context) (schema.Schemas, error)) Source {
+	return func(ctx context.Context) (string, error) {
+		schemas, err := list(ctx)
+		if err != nil {
+			return "", fmt.Errorf(`fail to list schemas: %w`, err)
+		}
+		b, err := json.Marshal(schemas)
+		if err != nil {
+			return "", fmt.Errorf(`fail to marshal schemas: %w`, err)
+		}
+		h := sha256.Sum256(b)
+		return hex.EncodeToString(h[:]), nil
+	}
+}
+
+// Dynamic returns a Source of the sources resolved by resolve on every poll, e.g. from a config file that may change.
+func Dynamic(resolve func(ctx context.Context) ([]Source, error)) Source {
+	return func(ctx context.Context) (string, error) {
+		sources, err := resolve(ctx)
+		if err != nil {
+			return "", fmt.Errorf(`fail to resolve sources: %w`, err)
+		}
+		return Watcher{Sources: sources}.poll(ctx)
+	}
+}
+
+// Result is the result of a run, or of a poll of the sources if Polled is true.
+type Result struct {
+	Start    time.Time
+	Duration time.Duration
+	Polled   bool
+	Err      error
+}
+
+// Watcher runs Run once and whenever the fingerprints of Sources change.
+type Watcher struct {
+	Sources []Source
+	// Interval is the interval of polling the sources, which defaults to 1s.
+	Interval time.Duration
+	// Debounce is the duration for which the sources must be unchanged before a run, which defaults to 300ms.
+	Debounce time.Duration
+	Run      func(ctx context.Context) error
+	// Report receives the results of the runs and the errors of polling the sources if it is not nil.
+	Report func(Result)
+}
+
+// Watch runs and polls until ctx is done, where the errors of the runs and the polls are reported instead of stopping the watch.
+// It returns nil when ctx is done.
+func (w Watcher) Watch(ctx context.Context) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	debounce := w.Debounce
+	if debounce <= 0 {
+		debounce = 300 * time.Millisecond
+	}
+
+	last, err := w.poll(ctx)
+	pollFailed := err != nil
+	if pollFailed {
+		w.report(Result{Start: time.Now(), Polled: true, Err: err})
+	}
+	w.run(ctx)
+
+	ticker := time.NewTicker(min(interval, debounce))
+	defer ticker.Stop()
+	var (
+		lastPoll  time.Time
+		changedAt time.Time
+		pending   bool
+		current   = last
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			if !pending && now.Sub(lastPoll) < interval {
+				continue
+			}
+			lastPoll = now
+			fp, err := w.poll(ctx)
+			if err != nil {
+				if !pollFailed && ctx.Err() == nil {
+					w.report(Result{Start: now, Polled: true, Err: err})
+				}
+				pollFailed = true
+				continue
+			}
+			pollFailed = false
+			if fp != current {
+				current, changedAt, pending = fp, now, true
+				continue
+			}
+			if pending && now.Sub(changedAt) >= debounce {
+				pending = false
+				if current != last {
+					last = current
+					w.run(ctx)
+				}
+			}
+		}
+	}
+}
+
+func (w Watcher) poll(ctx context.Context) (string, error) {
+	h := sha256.New()
+	for _, s := range w.Sources {
+		fp, err := s(ctx)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintln(h, fp)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (w Watcher) run(ctx context.Context) {
+	start := time.Now()
+	err := w.Run(ctx)
+	if ctx.Err() != nil {
+		return
+	}
+	w.report(Result{Start: start, Duration: time.Since(start), Err: err})
+}
+
+func (w Watcher) report(r Result) {
+	if w.Report != nil {
+		w.Report(r)
+	}
+}
@@ -0,0 +1,160 @@
+package watch_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/Jumpaku/schenerate/schema"
+	"github.com/Jumpaku/schenerate/watch"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.Nil(t, os.WriteFile(filepath.Join(dir, "a.sql"), []byte("a"), 0644))
+	source := watch.Files(dir, filepath.Join(t.TempDir(), "missing.sql"))
+
+	fp1, err := source(context.Background())
+	require.Nil(t, err)
+	fp2, err := source(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, fp1, fp2)
+
+	require.Nil(t, os.MkdirAll(filepath.Join(dir, "sub"), 0755))
+	require.Nil(t, os.WriteFile(filepath.Join(dir, "sub", "b.sql"), []byte("b"), 0644))
+	fp3, err := source(context.Background())
+	require.Nil(t, err)
+	require.NotEqual(t, fp1, fp3)
+
+	require.Nil(t, os.WriteFile(filepath.Join(dir, "sub", "b.sql"), []byte("c"), 0644))
+	fp4, err := source(context.Background())
+	require.Nil(t, err)
+	require.NotEqual(t, fp3, fp4)
+}
+
+func TestSchemas(t *testing.T) {
+	schemas := schema.Schemas{{Name: "users"}}
+	source := watch.Schemas(func(ctx context.Context) (schema.Schemas, error) { return schemas, nil })
+
+	fp1, err := source(context.Background())
+	require.Nil(t, err)
+	schemas = schema.Schemas{{Name: "users", Columns: []schema.Column{{Name: "id"}}}}
+	fp2, err := source(context.Background())
+	require.Nil(t, err)
+	require.NotEqual(t, fp1, fp2)
+
+	_, err = watch.Schemas(func(ctx context.Context) (schema.Schemas, error) { return nil, fmt.Errorf(`down`) })(context.Background())
+	require.NotNil(t, err)
+}
+
+func TestDynamic(t *testing.T) {
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a.sql"), filepath.Join(dir, "b.sql")
+	require.Nil(t, os.WriteFile(a, []byte("a"), 0644))
+	require.Nil(t, os.WriteFile(b, []byte("b"), 0644))
+	paths := []string{a}
+	source := watch.Dynamic(func(ctx context.Context) ([]watch.Source, error) { return []watch.Source{watch.Files(paths...)}, nil })
+
+	fp1, err := source(context.Background())
+	require.Nil(t, err)
+	require.Nil(t, os.WriteFile(b, []byte("c"), 0644))
+	fp2, err := source(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, fp1, fp2)
+
+	paths = []string{a, b}
+	fp3, err := source(context.Background())
+	require.Nil(t, err)
+	require.NotEqual(t, fp2, fp3)
+	require.Nil(t, os.WriteFile(b, []byte("d"), 0644))
+	fp4, err := source(context.Background())
+	require.Nil(t, err)
+	require.NotEqual(t, fp3, fp4)
+
+	_, err = watch.Dynamic(func(ctx context.Context) ([]watch.Source, error) { return nil, fmt.Errorf(`invalid`) })(context.Background())
+	require.NotNil(t, err)
+}
+
+// fake is a Source whose fingerprint and error are set by the test.
+type fake struct {
+	mu          sync.Mutex
+	fingerprint string
+	err         error
+}
+
+func (f *fake) set(fingerprint string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fingerprint, f.err = fingerprint, err
+}
+
+func (f *fake) source(context.Context) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.fingerprint, f.err
+}
+
+func TestWatcher_Watch(t *testing.T) {
+	src := &fake{fingerprint: "v1"}
+	results := make(chan watch.Result, 100)
+	runs := 0
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- watch.Watcher{
+			Sources:  []watch.Source{src.source},
+			Interval: 5 * time.Millisecond,
+			Debounce: 50 * time.Millisecond,
+			Run: func(ctx context.Context) error {
+				runs++
+				if runs == 2 {
+					return fmt.Errorf(`generation failed`)
+				}
+				return nil
+			},
+			Report: func(r watch.Result) { results <- r },
+		}.Watch(ctx)
+	}()
+	next := func() watch.Result {
+		select {
+		case r := <-results:
+			return r
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout")
+			return watch.Result{}
+		}
+	}
+
+	// initial run
+	r := next()
+	require.False(t, r.Polled)
+	require.Nil(t, r.Err)
+
+	// changes within the debounce are coalesced into a run, whose error does not stop the watch
+	src.set("v2", nil)
+	time.Sleep(10 * time.Millisecond)
+	src.set("v3", nil)
+	r = next()
+	require.False(t, r.Polled)
+	require.NotNil(t, r.Err)
+
+	// errors of polling are reported once
+	src.set("", fmt.Errorf(`unavailable`))
+	r = next()
+	require.True(t, r.Polled)
+	require.NotNil(t, r.Err)
+
+	src.set("v4", nil)
+	r = next()
+	require.False(t, r.Polled)
+	require.Nil(t, r.Err)
+
+	cancel()
+	require.Nil(t, <-done)
+	require.Equal(t, 3, runs)
+	require.Empty(t, results)
+}